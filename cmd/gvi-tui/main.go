@@ -1,20 +1,30 @@
 // Command gvi-tui is the terminal user interface for Gastown Viewer Intent.
-// It connects to the gvid daemon to display issue board and details.
+// It connects to the gvid daemon to display issue board and details, or
+// with -dir or -town reads beads and Gas Town directly.
 package main
 
 import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/tui"
 )
 
 const version = "0.1.0"
 
 func main() {
-	apiURL := flag.String("api", "http://localhost:7070", "API server URL")
+	apiURL := flag.String("api", "http://localhost:7070", "API server URL, or a comma-separated list of name=url endpoints to switch between")
+	workDir := flag.String("dir", "", "Read beads in this directory directly instead of connecting to gvid")
+	townRoot := flag.String("town", "", "Gas Town workspace root when reading directly (default: ~/gt)")
+	dataDir := flag.String("data", filepath.Join(os.Getenv("HOME"), ".gvid"), "Directory holding saved views when reading directly (empty disables them)")
+	statusFile := flag.String("statuses", "", "JSON file mapping raw bd statuses when reading directly")
+	boardFile := flag.String("board", "", "JSON file defining board columns when reading directly")
+	configFile := flag.String("config", tui.DefaultConfigPath(), "JSON config file for endpoints, keys, theme, default view and refresh interval")
 	showVersion := flag.Bool("version", false, "Show version and exit")
 	flag.Parse()
 
@@ -23,11 +33,70 @@ func main() {
 		os.Exit(0)
 	}
 
-	m := tui.New(*apiURL)
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	local := set["dir"] || set["town"]
+
+	// A missing config file is only an error when named explicitly
+	var cfg tui.Config
+	if *configFile != "" {
+		data, err := os.ReadFile(*configFile)
+		switch {
+		case err == nil:
+			if cfg, err = tui.ParseConfig(data); err != nil {
+				fatalf("Invalid config %s: %v", *configFile, err)
+			}
+		case !os.IsNotExist(err) || set["config"]:
+			fatalf("Failed to read config: %v", err)
+		}
+	}
+
+	var m tui.Model
+	if local {
+		config := tui.LocalConfig{Dir: *workDir, TownRoot: *townRoot, DataDir: *dataDir}
+		if *statusFile != "" {
+			data, err := os.ReadFile(*statusFile)
+			if err != nil {
+				fatalf("Failed to read status map: %v", err)
+			}
+			if config.Statuses, err = beads.ParseStatusMap(data); err != nil {
+				fatalf("Invalid status map: %v", err)
+			}
+		}
+		if *boardFile != "" {
+			data, err := os.ReadFile(*boardFile)
+			if err != nil {
+				fatalf("Failed to read board config: %v", err)
+			}
+			if config.Board, err = model.ParseBoardConfig(data); err != nil {
+				fatalf("Invalid board config: %v", err)
+			}
+		}
+		backend, err := tui.NewLocal(config)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		m = tui.NewWithBackend(backend)
+	} else {
+		endpoints := cfg.Endpoints
+		if set["api"] || len(endpoints) == 0 {
+			var err error
+			if endpoints, err = tui.ParseEndpoints(*apiURL); err != nil {
+				fatalf("Invalid -api: %v", err)
+			}
+		}
+		m = tui.NewWithEndpoints(endpoints)
+	}
+	m = m.WithConfig(cfg)
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fatalf("%v", err)
 	}
 }
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/pkg/client"
+)
+
+// queryFlags are the issue query flags shared by issues, board and graph.
+type queryFlags struct {
+	q, view string
+}
+
+func (f *queryFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.q, "q", "", "Issue query, such as 'status:blocked priority:high'")
+	fs.StringVar(&f.view, "view", "", "Saved view to apply")
+}
+
+func (f *queryFlags) query() client.IssueQuery {
+	return client.IssueQuery{Q: f.q, View: f.view}
+}
+
+// noArgs rejects positional arguments.
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return usageErrorf("unexpected argument %q", args[0])
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func runIssues(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("issues", true)
+	var qf queryFlags
+	qf.register(fs)
+	status := fs.String("status", "", "Filter by status")
+	priority := fs.String("priority", "", "Filter by priorities, comma-separated")
+	issueType := fs.String("type", "", "Filter by issue types, comma-separated")
+	sortBy := fs.String("sort", "", "Sort order, such as priority or -updated")
+	limit := fs.Int("limit", 0, "Maximum number of issues")
+	check := fs.Bool("check", false, "Exit with status 5 if any issues match")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && args[0] == "list" {
+		args = args[1:]
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	q := qf.query()
+	q.Status, q.Type, q.Sort = *status, splitList(*issueType), *sortBy
+	for _, priority := range splitList(*priority) {
+		q.Priority = append(q.Priority, client.Priority(priority))
+	}
+
+	resp, err := opts.client().Issues(ctx, q, client.ListOptions{Limit: *limit})
+	if err != nil {
+		return err
+	}
+	err = printList(p, resp.Issues, "ID\tSTATUS\tPRIORITY\tTYPE\tASSIGNEE\tTITLE", func(i client.Issue) string {
+		return fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", i.ID, i.Status, i.Priority, orDash(i.IssueType), orDash(i.Assignee), i.Title)
+	})
+	if err == nil && *check && len(resp.Issues) > 0 {
+		return errCheck
+	}
+	return err
+}
+
+func runIssue(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("issue", true)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && args[0] == "show" {
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return usageErrorf("usage: gvictl issue show <id>")
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	issue, err := opts.client().Issue(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return printOne(p, issue, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", issue.ID)
+		fmt.Fprintf(w, "Title:\t%s\n", issue.Title)
+		fmt.Fprintf(w, "Status:\t%s\n", issue.Status)
+		fmt.Fprintf(w, "Priority:\t%s\n", issue.Priority)
+		fmt.Fprintf(w, "Type:\t%s\n", orDash(issue.IssueType))
+		fmt.Fprintf(w, "Assignee:\t%s\n", orDash(issue.Assignee))
+		if len(issue.Labels) > 0 {
+			fmt.Fprintf(w, "Labels:\t%s\n", strings.Join(issue.Labels, ", "))
+		}
+		if issue.Parent != nil {
+			fmt.Fprintf(w, "Parent:\t%s\n", issue.Parent.ID)
+		}
+		for _, rel := range []struct {
+			label  string
+			issues []client.IssueSummary
+		}{{"Blocks", issue.Blocks}, {"Blocked by", issue.BlockedBy}, {"Children", issue.Children}} {
+			if len(rel.issues) == 0 {
+				continue
+			}
+			ids := make([]string, len(rel.issues))
+			for i, s := range rel.issues {
+				ids[i] = s.ID
+			}
+			fmt.Fprintf(w, "%s:\t%s\n", rel.label, strings.Join(ids, ", "))
+		}
+		fmt.Fprintf(w, "Updated:\t%s ago\n", ago(issue.UpdatedAt))
+		if issue.Description != "" {
+			fmt.Fprintf(w, "\n%s\n", issue.Description)
+		}
+	})
+}
+
+func runBoard(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("board", true)
+	var qf queryFlags
+	qf.register(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	board, err := opts.client().Board(ctx, qf.query())
+	if err != nil {
+		return err
+	}
+	return printOne(p, board, func(w io.Writer) {
+		fmt.Fprintln(w, "COLUMN\tID\tPRIORITY\tTITLE")
+		for _, col := range board.Columns {
+			label := fmt.Sprintf("%s (%d)", col.Label, col.Count)
+			if col.WIPLimit > 0 {
+				label = fmt.Sprintf("%s (%d/%d)", col.Label, col.Count, col.WIPLimit)
+			}
+			if len(col.Issues) == 0 {
+				fmt.Fprintf(w, "%s\t-\t-\t-\n", label)
+			}
+			for _, issue := range col.Issues {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", label, issue.ID, issue.Priority, issue.Title)
+			}
+		}
+	})
+}
+
+func runGraph(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("graph", false)
+	var qf queryFlags
+	qf.register(fs)
+	format := fs.String("format", "json", "Graph format: json, dot or mermaid")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+
+	c := opts.client()
+	switch *format {
+	case "json":
+		graph, err := c.Graph(ctx, qf.query())
+		if err != nil {
+			return err
+		}
+		return (&printer{w: stdout, json: true}).writeJSON(graph)
+	case "dot", "mermaid":
+		text, err := c.GraphText(ctx, client.GraphFormat(*format), qf.query())
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, text)
+		return err
+	}
+	return usageErrorf("invalid format %q, want json, dot or mermaid", *format)
+}
+
+func runTown(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("town", true)
+	check := fs.Bool("check", false, "Exit with status 5 if the town is unhealthy")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || args[0] != "status" {
+		return usageErrorf("usage: gvictl town status")
+	}
+	if err := noArgs(args[1:]); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	status, err := opts.client().TownStatus(ctx)
+	if err != nil {
+		return err
+	}
+	err = printOne(p, status, func(w io.Writer) {
+		healthy := "yes"
+		if !status.Healthy {
+			healthy = "no"
+		}
+		fmt.Fprintf(w, "Healthy:\t%s\n", healthy)
+		fmt.Fprintf(w, "Town root:\t%s\n", orDash(status.TownRoot))
+		fmt.Fprintf(w, "Agents:\t%d active of %d\n", status.ActiveAgents, status.TotalAgents)
+		fmt.Fprintf(w, "Rigs:\t%d\n", status.ActiveRigs)
+		fmt.Fprintf(w, "Open convoys:\t%d\n", status.OpenConvoys)
+		if status.Error != "" {
+			fmt.Fprintf(w, "Error:\t%s\n", status.Error)
+		}
+	})
+	if err == nil && *check && !status.Healthy {
+		return errCheck
+	}
+	return err
+}
+
+func runAgents(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("agents", true)
+	status := fs.String("status", "", "Filter by statuses, comma-separated, such as stuck,offline")
+	rig := fs.String("rig", "", "Filter by rig")
+	role := fs.String("role", "", "Filter by roles, comma-separated")
+	check := fs.Bool("check", false, "Exit with status 5 if any agents match")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	all, err := opts.client().Agents(ctx)
+	if err != nil {
+		return err
+	}
+	statuses, roles := splitList(*status), splitList(*role)
+	var agents []client.Agent
+	for _, a := range all {
+		if matches(statuses, string(a.Status)) && matches(roles, string(a.Role)) && (*rig == "" || a.Rig == *rig) {
+			agents = append(agents, a)
+		}
+	}
+
+	err = printList(p, agents, "NAME\tROLE\tRIG\tSTATUS\tHOOK\tACTIVE", func(a client.Agent) string {
+		return fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", a.Name, a.Role, orDash(a.Rig), a.Status, orDash(a.HookBead), ago(a.LastActive))
+	})
+	if err == nil && *check && len(agents) > 0 {
+		return errCheck
+	}
+	return err
+}
+
+func runConvoys(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("convoys", true)
+	status := fs.String("status", "", "Filter by statuses, comma-separated, such as blocked,failed")
+	check := fs.Bool("check", false, "Exit with status 5 if any convoys match")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	all, err := opts.client().Convoys(ctx)
+	if err != nil {
+		return err
+	}
+	statuses := splitList(*status)
+	var convoys []client.Convoy
+	for _, c := range all {
+		if matches(statuses, string(c.Status)) {
+			convoys = append(convoys, c)
+		}
+	}
+
+	err = printList(p, convoys, "ID\tSTATUS\tPROGRESS\tBLOCKED\tTITLE", func(c client.Convoy) string {
+		return fmt.Sprintf("%s\t%s\t%d/%d\t%d\t%s", c.ID, c.Status, c.Completed, c.Total, c.Blocked, c.Title)
+	})
+	if err == nil && *check && len(convoys) > 0 {
+		return errCheck
+	}
+	return err
+}
+
+// matches reports whether v is in want, or want is empty.
+func matches(want []string, v string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, w := range want {
+		if strings.EqualFold(w, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamEvent is an event as printed by gvictl events.
+type streamEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+	Time  time.Time       `json:"time"`
+}
+
+func runEvents(ctx context.Context, args []string) error {
+	fs, opts := newFlagSet("events", true)
+	follow := fs.Bool("follow", false, "Keep printing events until interrupted; without it, print the connected event and exit")
+	types := fs.String("type", "", "Event types to print with -follow, comma-separated, such as issue_updated")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := noArgs(args); err != nil {
+		return err
+	}
+	p, err := opts.printer()
+	if err != nil {
+		return err
+	}
+
+	// Heartbeats, like the connected event, are only printed when asked for
+	want := splitList(*types)
+	var subOpts []client.SubscribeOption
+	if len(want) > 0 && matches(want, client.EventHeartbeat) {
+		subOpts = append(subOpts, client.WithHeartbeats())
+	}
+	sub, err := opts.client().Subscribe(ctx, subOpts...)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	printEvent := func(event client.Event) error {
+		e := streamEvent{Event: event.Type, Data: event.Data, Time: time.Now()}
+		switch {
+		case p.json:
+			// One object per line, for piping into jq
+			return json.NewEncoder(p.w).Encode(e)
+		case p.tmpl != nil:
+			return p.execute(e)
+		default:
+			_, err := fmt.Fprintf(p.w, "%s  %-14s %s\n", e.Time.Format("15:04:05"), e.Event, e.Data)
+			return err
+		}
+	}
+
+	// Without -follow, the connected event shows the stream is reachable;
+	// waiting for a change could block forever on an idle board
+	if !*follow {
+		if !sub.Next() {
+			return sub.Err()
+		}
+		return printEvent(sub.Event())
+	}
+
+	for sub.Next() {
+		event := sub.Event()
+		// The connected event is only printed when asked for
+		if len(want) == 0 && event.Type == client.EventConnected || len(want) > 0 && !matches(want, event.Type) {
+			continue
+		}
+		if err := printEvent(event); err != nil {
+			return err
+		}
+	}
+	if errors.Is(sub.Err(), context.Canceled) {
+		return nil
+	}
+	return sub.Err()
+}
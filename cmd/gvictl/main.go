@@ -0,0 +1,205 @@
+// Command gvictl is a command-line client for the gvid API, made for
+// scripts and cron jobs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/pkg/client"
+)
+
+// version is set by goreleaser ldflags at build time
+var version = "dev"
+
+// stdout and stderr are where gvictl writes; tests replace them.
+var (
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// Exit codes
+const (
+	exitOK          = 0
+	exitError       = 1 // gvid returned an error
+	exitUsage       = 2 // bad command line
+	exitUnreachable = 3 // gvid could not be reached, or the event stream dropped
+	exitNotFound    = 4 // the issue or resource does not exist
+	exitCheck       = 5 // -check matched: results were found or the town is unhealthy
+)
+
+// errCheck is returned when a -check condition matched. The results have
+// already been printed.
+var errCheck = errors.New("check matched")
+
+// usageError is a bad command line.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...any) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+// command is a gvictl subcommand.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"issues", "[list] [flags]", "List issues", runIssues},
+		{"issue", "show <id> [flags]", "Show one issue", runIssue},
+		{"board", "[flags]", "Show the kanban board", runBoard},
+		{"graph", "[flags]", "Print the dependency graph as JSON, DOT or Mermaid", runGraph},
+		{"town", "status [flags]", "Show Gas Town health", runTown},
+		{"agents", "[flags]", "List Gas Town agents", runAgents},
+		{"convoys", "[flags]", "List convoys", runConvoys},
+		{"events", "[flags]", "Check the event stream, or print live events with -follow", runEvents},
+		{"version", "", "Show version", runVersion},
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:])
+	stop()
+
+	code := exitCode(err)
+	if err != nil && code != exitOK && code != exitCheck {
+		fmt.Fprintf(stderr, "gvictl: %v\n", err)
+		if code == exitUsage {
+			fmt.Fprintln(stderr, "Run 'gvictl help' for usage.")
+		}
+	}
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return &usageError{"no command given"}
+	}
+	name, args := args[0], args[1:]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return nil
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(ctx, args)
+		}
+	}
+	return usageErrorf("unknown command %q", name)
+}
+
+// exitCode maps an error to the process exit code.
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errCheck):
+		return exitCheck
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, client.ErrUnreachable):
+		return exitUnreachable
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	}
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gvictl <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %-18s %s\n", c.name, c.args, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Common flags:")
+	fmt.Fprintln(w, "  -api URL        gvid address (default $GVID_API or http://localhost:7070)")
+	fmt.Fprintln(w, "  -o FORMAT       table, json or go-template=TEMPLATE")
+	fmt.Fprintln(w, "  -timeout DUR    request timeout (default 10s)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes: 0 ok, 1 API error, 2 usage, 3 gvid unreachable, 4 not found,")
+	fmt.Fprintln(w, "5 -check matched. Run 'gvictl <command> -h' for a command's flags.")
+}
+
+// options are the flags shared by every command.
+type options struct {
+	api     string
+	output  string
+	timeout time.Duration
+}
+
+// newFlagSet returns a flag set with the common flags. withOutput adds -o.
+func newFlagSet(name string, withOutput bool) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet("gvictl "+name, flag.ContinueOnError)
+	opts := &options{}
+	api := os.Getenv("GVID_API")
+	if api == "" {
+		api = "http://localhost:7070"
+	}
+	fs.StringVar(&opts.api, "api", api, "gvid address")
+	fs.DurationVar(&opts.timeout, "timeout", 10*time.Second, "Request timeout")
+	if withOutput {
+		fs.StringVar(&opts.output, "o", "table", "Output format: table, json or go-template=TEMPLATE")
+	}
+	return fs, opts
+}
+
+// parseFlags parses args, allowing flags after positional arguments, and
+// returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				fs.SetOutput(stdout)
+				fs.PrintDefaults()
+				return nil, err
+			}
+			return nil, &usageError{err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (o *options) client() *client.Client {
+	return client.New(o.api, client.WithTimeout(o.timeout), client.WithUserAgent("gvictl/"+version))
+}
+
+func (o *options) printer() (*printer, error) {
+	return newPrinter(stdout, o.output)
+}
+
+func runVersion(ctx context.Context, args []string) error {
+	fmt.Fprintf(stdout, "gvictl version %s\n", version)
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/issues":
+			if r.URL.Query().Get("status") == "done" {
+				fmt.Fprint(w, `{"issues":[],"total":0}`)
+				return
+			}
+			fmt.Fprint(w, `{"issues":[
+				{"id":"gt-1","title":"Fix login","status":"blocked","priority":"high"},
+				{"id":"gt-2","title":"Add export","status":"pending","priority":"low"}],"total":2}`)
+		case "/api/v1/issues/gt-1":
+			fmt.Fprint(w, `{"id":"gt-1","title":"Fix login","status":"blocked","priority":"high"}`)
+		case "/api/v1/issues/gt-404":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"issue not found: gt-404","code":"ISSUE_NOT_FOUND"}`)
+		case "/api/v1/board":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"unknown field \"colour\"","code":"INVALID_QUERY"}`)
+		case "/api/v1/graph":
+			fmt.Fprint(w, "flowchart LR\n  gt-2 --> gt-1\n")
+		case "/api/v1/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: connected\ndata: {}\n\nevent: heartbeat\ndata: {}\n\n")
+			fmt.Fprint(w, "event: issue_updated\ndata: {\"id\":\"gt-1\"}\n\n")
+		case "/api/v1/town/status":
+			fmt.Fprint(w, `{"healthy":false,"active_agents":1,"total_agents":3,"error":"deacon is down"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// runCmd runs gvictl with args and returns its exit code and output.
+func runCmd(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	var out bytes.Buffer
+	oldOut, oldErr := stdout, stderr
+	stdout, stderr = &out, &out
+	t.Cleanup(func() { stdout, stderr = oldOut, oldErr })
+	err := run(context.Background(), args)
+	return exitCode(err), out.String()
+}
+
+func TestRun(t *testing.T) {
+	api := newTestServer(t).URL
+	t.Setenv("GVID_API", api)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string // expected in the output
+	}{
+		{"list", []string{"issues", "list"}, exitOK, "gt-2  pending  low"},
+		{"flags after arguments", []string{"issue", "show", "gt-1", "-o", "json"}, exitOK, `"id": "gt-1"`},
+		{"go template", []string{"issues", "-o", "go-template={{.ID}}:{{.Priority}}"}, exitOK, "gt-1:high\ngt-2:low\n"},
+		{"check matched", []string{"issues", "-check", "-q", "status:blocked"}, exitCheck, "gt-1"},
+		{"check clear", []string{"issues", "-status", "done", "-check"}, exitOK, "ID"},
+		{"unhealthy town", []string{"town", "status", "-check"}, exitCheck, "deacon is down"},
+		{"mermaid", []string{"graph", "-format", "mermaid"}, exitOK, "gt-2 --> gt-1"},
+		{"handshake", []string{"events", "-o", "go-template={{.Event}} {{.Data}}"}, exitOK, "connected {}\n"},
+		{"filtered events", []string{"events", "-follow", "-type", "issue_updated", "-o", "go-template={{.Event}} {{.Data}}"}, exitUnreachable, "issue_updated {\"id\":\"gt-1\"}\n"},
+		{"heartbeats", []string{"events", "-follow", "-type", "heartbeat", "-o", "json"}, exitUnreachable, `"event":"heartbeat"`},
+		{"stream dropped", []string{"events", "-follow", "-type", "connected"}, exitUnreachable, "connected"},
+		{"not found", []string{"issue", "show", "gt-404"}, exitNotFound, ""},
+		{"API error", []string{"board", "-q", "colour:red"}, exitError, ""},
+		{"unknown command", []string{"frobnicate"}, exitUsage, ""},
+		{"unknown flag", []string{"issues", "-colour"}, exitUsage, ""},
+		{"bad output", []string{"issues", "-o", "yaml"}, exitUsage, ""},
+		{"bad template", []string{"issues", "-o", "go-template={{.ID"}, exitUsage, ""},
+		{"missing ID", []string{"issue", "show"}, exitUsage, ""},
+		{"help", []string{"issues", "-h"}, exitOK, "-check"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runCmd(t, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d; output:\n%s", code, tt.code, out)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, out)
+			}
+		})
+	}
+}
+
+func TestRunUnreachable(t *testing.T) {
+	t.Setenv("GVID_API", "http://127.0.0.1:1")
+	code, out := runCmd(t, "town", "status", "-timeout", "1s")
+	if code != exitUnreachable {
+		t.Errorf("exit code %d, want %d; output:\n%s", code, exitUnreachable, out)
+	}
+
+	// -api overrides $GVID_API
+	code, out = runCmd(t, "issues", "-o", "json", "-api", newTestServer(t).URL)
+	if code != exitOK || !strings.Contains(out, `"title": "Add export"`) {
+		t.Errorf("exit code %d with -api; output:\n%s", code, out)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+)
+
+// printer writes command results as a table, JSON or a Go template.
+type printer struct {
+	w    io.Writer
+	json bool
+	tmpl *template.Template // nil for tables
+}
+
+// newPrinter parses an -o value: "table", "json" or "go-template=TEMPLATE".
+func newPrinter(w io.Writer, output string) (*printer, error) {
+	switch {
+	case output == "" || output == "table":
+		return &printer{w: w}, nil
+	case output == "json":
+		return &printer{w: w, json: true}, nil
+	case strings.HasPrefix(output, "go-template="):
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(strings.TrimPrefix(output, "go-template="))
+		if err != nil {
+			return nil, usageErrorf("invalid template: %v", err)
+		}
+		return &printer{w: w, tmpl: tmpl}, nil
+	}
+	return nil, usageErrorf("invalid output %q, want table, json or go-template=TEMPLATE", output)
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": strings.Join,
+	"ago":  ago,
+}
+
+// printList writes items as a table with one row per item, a JSON array,
+// or the template executed once per item.
+func printList[T any](p *printer, items []T, header string, row func(T) string) error {
+	switch {
+	case p.json:
+		if items == nil {
+			items = []T{}
+		}
+		return p.writeJSON(items)
+	case p.tmpl != nil:
+		for _, item := range items {
+			if err := p.execute(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, header)
+	for _, item := range items {
+		fmt.Fprintln(tw, row(item))
+	}
+	return tw.Flush()
+}
+
+// printOne writes a single object with table, as JSON, or through the
+// template.
+func printOne[T any](p *printer, v T, table func(io.Writer)) error {
+	switch {
+	case p.json:
+		return p.writeJSON(v)
+	case p.tmpl != nil:
+		return p.execute(v)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func (p *printer) writeJSON(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// execute runs the template on v, ending the output with a newline.
+func (p *printer) execute(v any) error {
+	var b strings.Builder
+	if err := p.tmpl.Execute(&b, v); err != nil {
+		return err
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(p.w, out)
+	return err
+}
+
+// ago formats the time since t compactly, such as "5m" or "3d".
+func ago(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// orDash returns s, or "-" for an empty table cell.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -3,26 +3,43 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/api"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
 )
 
 // version is set by goreleaser ldflags at build time
 var version = "dev"
 
 func main() {
+	// The archive lives in the home directory; without one it is disabled
+	// rather than written relative to the working directory
+	defaultDataDir := ""
+	home, homeErr := os.UserHomeDir()
+	if homeErr == nil {
+		defaultDataDir = filepath.Join(home, ".gvid")
+	}
+
 	// Parse flags
 	port := flag.Int("port", 7070, "HTTP server port")
 	host := flag.String("host", "localhost", "HTTP server host")
 	workDir := flag.String("dir", "", "Working directory (default: current directory)")
 	townRoot := flag.String("town", "", "Gas Town workspace root (default: ~/gt)")
+	dataDir := flag.String("data", defaultDataDir, "Directory for gvid's local archive (empty disables it)")
+	snapshotInterval := flag.Duration("snapshot-interval", time.Minute, "How often to snapshot beads and Gas Town state")
+	statusFile := flag.String("statuses", "", "JSON file mapping raw bd statuses to pending, in_progress, done or blocked")
+	boardFile := flag.String("board", "", "JSON file defining board columns and WIP limits (default: four standard columns)")
+	allowWrites := flag.Bool("allow-writes", false, "Let API requests change issues (off by default)")
 	showVersion := flag.Bool("version", false, "Show version and exit")
 	flag.Parse()
 
@@ -30,9 +47,23 @@ func main() {
 		fmt.Printf("gvid version %s\n", version)
 		os.Exit(0)
 	}
+	if *dataDir == "" && homeErr != nil {
+		log.Printf("Local archive disabled: %v; pass -data to enable it", homeErr)
+	}
 
 	// Create beads adapter
 	adapter := beads.NewCLIAdapter(*workDir)
+	if *statusFile != "" {
+		data, err := os.ReadFile(*statusFile)
+		if err != nil {
+			log.Fatalf("Failed to read status map: %v", err)
+		}
+		statuses, err := beads.ParseStatusMap(data)
+		if err != nil {
+			log.Fatalf("Invalid status map: %v", err)
+		}
+		adapter.SetStatusMap(statuses)
+	}
 
 	// Create server config
 	config := api.DefaultConfig()
@@ -40,6 +71,18 @@ func main() {
 	config.Host = *host
 	config.Version = version
 	config.TownRoot = *townRoot
+	config.DataDir = *dataDir
+	config.SnapshotInterval = *snapshotInterval
+	config.AllowWrites = *allowWrites
+	if *boardFile != "" {
+		data, err := os.ReadFile(*boardFile)
+		if err != nil {
+			log.Fatalf("Failed to read board config: %v", err)
+		}
+		if config.Board, err = model.ParseBoardConfig(data); err != nil {
+			log.Fatalf("Invalid board config: %v", err)
+		}
+	}
 
 	// Create and start server
 	server := api.NewServer(config, adapter)
@@ -51,6 +94,11 @@ func main() {
 	go func() {
 		<-done
 		log.Println("Shutting down...")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Shutdown: %v", err)
+		}
+		cancel()
 		os.Exit(0)
 	}()
 
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/export"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// defaultReportWindow is the period a Markdown report covers when since is
+// not given.
+const defaultReportWindow = 7 * 24 * time.Hour
+
+// handleExport handles GET /api/v1/export. It accepts the same filters as
+// /issues; Markdown reports also take since and until.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	if !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+	params := r.URL.Query()
+	now := time.Now()
+
+	format := export.FormatCSV
+	if v := params.Get("format"); v != "" {
+		var ok bool
+		if format, ok = export.ParseFormat(v); !ok {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAM",
+				fmt.Sprintf("invalid format %q: use csv, jsonl or markdown", v))
+			return
+		}
+	}
+
+	since, until, err := parseTimeRange(params, now)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+	if since.IsZero() {
+		since = now.Add(-defaultReportWindow)
+	}
+	if until.IsZero() {
+		until = now
+	}
+
+	q, ok := s.parseIssueQuery(w, r)
+	if !ok {
+		return
+	}
+
+	issues, err := s.adapter.ListIssues(ctx, q.Filter)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+	issues = q.Query.Filter(issues, now)
+	model.SortIssues(issues, q.Sort)
+
+	work := s.workIndex(ctx)
+	for i := range issues {
+		work.Annotate(&issues[i])
+	}
+
+	// Render into a buffer so failures can still be reported as errors
+	var buf bytes.Buffer
+	filename := "issues." + format.Extension()
+	switch format {
+	case export.FormatCSV:
+		err = export.WriteCSV(&buf, issues)
+	case export.FormatJSONL:
+		err = export.WriteJSONL(&buf, issues)
+	case export.FormatMarkdown:
+		agents, _ := s.gtAdapter.Agents(ctx)
+		convoys, _ := s.gtAdapter.Convoys(ctx)
+		board := model.BuildBoard(s.config.Board, issues, model.GroupByNone)
+		report := export.NewReport(issues, board, agents, convoys, since, until, now)
+		err = report.WriteMarkdown(&buf)
+		filename = "report-" + until.Format("2006-01-02") + ".md"
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "EXPORT_ERROR", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -1,9 +1,15 @@
 package api
 
 import (
+	"context"
+	"mime"
 	"net/http"
+	"time"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/join"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
 )
 
 // handleTownStatus handles GET /api/v1/town/status.
@@ -172,15 +178,64 @@ func (s *Server) handleMail(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleMolecules handles GET /api/v1/town/molecules.
+//
+// By default only molecules of currently enumerated agents are returned.
+// include=completed adds archived molecules that finished (complete or
+// failed) and include=all adds every archived molecule; since and until
+// restrict results to molecules active within that window.
 func (s *Server) handleMolecules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	query := r.URL.Query()
+	now := time.Now()
 
-	molecules, err := s.gtAdapter.Molecules(ctx)
+	include := query.Get("include")
+	if include != "" && include != "completed" && include != "all" {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", "include must be completed or all")
+		return
+	}
+
+	since, until, err := parseTimeRange(query, now)
 	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	if include != "" && s.molecules == nil {
+		writeError(w, http.StatusServiceUnavailable, "ARCHIVE_DISABLED",
+			"Molecule archive is disabled. Start gvid with -data to enable it.")
+		return
+	}
+
+	active, err := s.gtAdapter.Molecules(ctx)
+	if err != nil && include == "" {
 		writeError(w, http.StatusInternalServerError, "GASTOWN_ERROR", err.Error())
 		return
 	}
 
+	var molecules []gastown.Molecule
+	seen := make(map[string]bool)
+	for _, m := range active {
+		seen[m.ID] = true
+		if activeWithin(m, now, since, until) {
+			molecules = append(molecules, m)
+		}
+	}
+
+	if include != "" {
+		q := store.MoleculeQuery{Since: since, Until: until}
+		if include == "completed" {
+			q.Statuses = []gastown.MoleculeStatus{gastown.MolStatusComplete, gastown.MolStatusFailed}
+		}
+		for _, archived := range s.molecules.Query(q) {
+			if seen[archived.Molecule.ID] {
+				continue
+			}
+			mol := archived.Molecule
+			mol.Archived = true
+			molecules = append(molecules, mol)
+		}
+	}
+
 	// Group by status
 	var inProgress, pending, complete, blocked int
 	for _, m := range molecules {
@@ -216,7 +271,7 @@ func (s *Server) handleMolecule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	molecule, err := s.gtAdapter.Molecule(ctx, id)
+	molecule, err := s.findMolecule(ctx, id)
 	if err != nil {
 		writeError(w, http.StatusNotFound, "MOLECULE_NOT_FOUND", err.Error())
 		return
@@ -224,3 +279,140 @@ func (s *Server) handleMolecule(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, molecule)
 }
+
+// findMolecule looks a molecule up among active agents, falling back to
+// the archive for molecules whose agent has since been recycled.
+func (s *Server) findMolecule(ctx context.Context, id string) (*gastown.Molecule, error) {
+	molecule, err := s.gtAdapter.Molecule(ctx, id)
+	if err == nil {
+		return molecule, nil
+	}
+	if s.molecules != nil {
+		if archived, ok := s.molecules.Get(id); ok {
+			mol := archived.Molecule
+			mol.Archived = true
+			return &mol, nil
+		}
+	}
+	return nil, err
+}
+
+// MoleculeGraphResponse is the step DAG of a molecule with timing analysis.
+type MoleculeGraphResponse struct {
+	model.Graph
+	gastown.MoleculeTiming
+}
+
+// handleMoleculeGraph handles GET /api/v1/town/molecules/{id}/graph.
+func (s *Server) handleMoleculeGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", "molecule ID required")
+		return
+	}
+
+	molecule, err := s.findMolecule(ctx, id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "MOLECULE_NOT_FOUND", err.Error())
+		return
+	}
+
+	graph := molecule.Graph()
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": id + ".dot"}))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(graph.ToDOT()))
+		return
+	default:
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", "format must be json or dot")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, MoleculeGraphResponse{
+		Graph:          graph,
+		MoleculeTiming: gastown.AnalyzeMolecule(molecule, time.Now()),
+	})
+}
+
+// handleFormulaStats handles GET /api/v1/town/formulas/stats.
+// When the archive is enabled, molecules from recycled agents are included
+// so the statistics cover every run gvid has observed.
+func (s *Server) handleFormulaStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	now := time.Now()
+
+	since, until, err := parseTimeRange(r.URL.Query(), now)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	active, err := s.gtAdapter.Molecules(ctx)
+	if err != nil && s.molecules == nil {
+		writeError(w, http.StatusInternalServerError, "GASTOWN_ERROR", err.Error())
+		return
+	}
+
+	var molecules []gastown.Molecule
+	seen := make(map[string]bool)
+	for _, m := range active {
+		seen[m.ID] = true
+		if activeWithin(m, now, since, until) {
+			molecules = append(molecules, m)
+		}
+	}
+	if s.molecules != nil {
+		// Live molecules are fresher than the recorder's last snapshot
+		for _, archived := range s.molecules.Query(store.MoleculeQuery{Since: since, Until: until}) {
+			if !seen[archived.Molecule.ID] {
+				molecules = append(molecules, archived.Molecule)
+			}
+		}
+	}
+
+	stats := gastown.ComputeFormulaStats(molecules)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"formulas": stats,
+		"total":    len(stats),
+	})
+}
+
+// activeWithin reports whether a live molecule was active between since and
+// until. A running molecule is active until now, a finished one until its
+// last update; one without a creation time is treated as starting then.
+// Zero bounds are open.
+func activeWithin(m gastown.Molecule, now, since, until time.Time) bool {
+	end := now
+	if (m.Status == gastown.MolStatusComplete || m.Status == gastown.MolStatusFailed) && !m.UpdatedAt.IsZero() {
+		end = m.UpdatedAt
+	}
+	start := m.CreatedAt
+	if start.IsZero() {
+		start = end
+	}
+	if !until.IsZero() && start.After(until) {
+		return false
+	}
+	return since.IsZero() || !end.Before(since)
+}
+
+// workIndex links issues to the agents and convoys working on them. It
+// uses the recorder's latest snapshot, reading Gas Town itself only when
+// the recorder has not run. Gas Town is optional, so read errors leave the
+// index empty rather than failing the request.
+func (s *Server) workIndex(ctx context.Context) *join.Index {
+	if idx := s.work.Load(); idx != nil {
+		return idx
+	}
+	agents, _ := s.gtAdapter.Agents(ctx)
+	convoys, _ := s.gtAdapter.Convoys(ctx)
+	molecules, _ := s.gtAdapter.Molecules(ctx)
+	return join.New(agents, convoys, molecules)
+}
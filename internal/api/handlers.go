@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/markdown"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
 )
 
@@ -16,6 +18,10 @@ type HealthResponse struct {
 	Version          string `json:"version"`
 	BDVersion        string `json:"bd_version,omitempty"`
 	Error            string `json:"error,omitempty"`
+
+	// UnmappedStatuses lists raw bd statuses with no status mapping; their
+	// issues are reported with status "unknown".
+	UnmappedStatuses []beads.UnmappedStatus `json:"unmapped_statuses,omitempty"`
 }
 
 // handleHealth handles GET /api/v1/health.
@@ -57,6 +63,7 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		resp.BDVersion = version
 	}
 
+	resp.UnmappedStatuses = s.adapter.UnmappedStatuses()
 	resp.Status = "ok"
 	writeJSON(w, http.StatusOK, resp)
 }
@@ -70,10 +77,11 @@ func (s *Server) handleListIssues(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	query := r.URL.Query()
 
-	filter := model.NewIssueFilter()
-	filter.Status = query.Get("status")
-	filter.Parent = query.Get("parent")
-	filter.Search = query.Get("search")
+	q, ok := s.parseIssueQuery(w, r)
+	if !ok {
+		return
+	}
+	filter := q.Filter
 
 	if limitStr := query.Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -87,15 +95,37 @@ func (s *Server) handleListIssues(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	renderHTML, err := parseRenderParam(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
 	issues, err := s.adapter.ListIssues(ctx, filter)
 	if err != nil {
 		handleAdapterError(w, err)
 		return
 	}
+	issues = q.Query.Filter(issues, time.Now())
+	model.SortIssues(issues, q.Sort)
+
+	// Page after filtering and sorting so Total counts every match.
+	total := len(issues)
+	issues = issues[min(filter.Offset, total):]
+	if filter.Limit > 0 && filter.Limit < len(issues) {
+		issues = issues[:filter.Limit]
+	}
+
+	if renderHTML {
+		link := s.refs.linker(issues...)
+		for i := range issues {
+			issues[i].DescriptionHTML = markdown.ToHTML(issues[i].Description, link)
+		}
+	}
 
 	resp := model.IssueListResponse{
 		Issues: issues,
-		Total:  len(issues),
+		Total:  total,
 		Limit:  filter.Limit,
 		Offset: filter.Offset,
 	}
@@ -111,9 +141,14 @@ func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	id := r.PathValue("id")
+	if err := model.ValidateIssueID(id); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
 
-	if id == "" {
-		writeError(w, http.StatusBadRequest, "INVALID_PARAM", "issue ID required")
+	renderHTML, err := parseRenderParam(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
 		return
 	}
 
@@ -123,6 +158,12 @@ func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.workIndex(ctx).Annotate(issue)
+
+	if renderHTML {
+		issue.DescriptionHTML = markdown.ToHTML(issue.Description, s.refs.linker(*issue))
+	}
+
 	writeJSON(w, http.StatusOK, issue)
 }
 
@@ -134,11 +175,21 @@ func (s *Server) handleBoard(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	board, err := s.adapter.Board(ctx)
+	q, ok := s.parseIssueQuery(w, r)
+	if !ok {
+		return
+	}
+
+	issues, err := s.adapter.ListIssues(ctx, q.Filter)
 	if err != nil {
 		handleAdapterError(w, err)
 		return
 	}
+	issues = q.Query.Filter(issues, time.Now())
+	model.SortIssues(issues, q.Sort)
+
+	board := model.BuildBoard(s.config.Board, issues, q.GroupBy)
+	s.workIndex(ctx).AnnotateBoard(&board)
 
 	writeJSON(w, http.StatusOK, board)
 }
@@ -161,12 +212,31 @@ func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
 		format = "json"
 	}
 
+	q, ok := s.parseIssueQuery(w, r)
+	if !ok {
+		return
+	}
+
 	graph, err := s.adapter.Graph(ctx)
 	if err != nil {
 		handleAdapterError(w, err)
 		return
 	}
 
+	if q.Filtered {
+		issues, err := s.adapter.ListIssues(ctx, q.Filter)
+		if err != nil {
+			handleAdapterError(w, err)
+			return
+		}
+		keep := make(map[string]bool, len(issues))
+		for _, issue := range q.Query.Filter(issues, time.Now()) {
+			keep[issue.ID] = true
+		}
+		sub := graph.SubGraph(keep)
+		graph = &sub
+	}
+
 	switch format {
 	case "dot":
 		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
@@ -174,6 +244,12 @@ func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(graph.ToDOT()))
 		return
+	case "mermaid":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", "inline; filename=\"dependencies.mmd\"")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(graph.ToMermaid()))
+		return
 	case "svg":
 		// SVG format placeholder - would require graphviz binary
 		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED",
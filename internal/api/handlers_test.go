@@ -1,12 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"mime"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
 )
 
 func TestHealthHandler(t *testing.T) {
@@ -100,3 +107,439 @@ func TestCORSPreflight(t *testing.T) {
 		t.Errorf("Expected status 204 for preflight, got %d", w.Code)
 	}
 }
+
+func TestMoleculesIncludeCompleted(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	config.DataDir = t.TempDir()
+	adapter := beads.NewCLIAdapter("")
+
+	server := NewServer(config, adapter)
+	if server.molecules == nil {
+		t.Fatal("expected molecule archive to be enabled")
+	}
+
+	err := server.molecules.Record([]gastown.Molecule{
+		{ID: "mol-done", Status: gastown.MolStatusComplete},
+		{ID: "mol-stalled", Status: gastown.MolStatusInProgress},
+	}, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/town/molecules?include=completed&since=1d", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Molecules []gastown.Molecule `json:"molecules"`
+		Total     int                `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if resp.Total != 1 || resp.Molecules[0].ID != "mol-done" {
+		t.Fatalf("Expected only mol-done, got %+v", resp.Molecules)
+	}
+	if !resp.Molecules[0].Archived {
+		t.Error("Expected archived molecule to be flagged")
+	}
+}
+
+func TestMoleculeGraphDOTFilename(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	config.DataDir = t.TempDir()
+	server := NewServer(config, beads.NewCLIAdapter(""))
+
+	id := `mol-"1"; x`
+	if err := server.molecules.Record([]gastown.Molecule{{ID: id, Status: gastown.MolStatusComplete}}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/town/molecules/"+url.PathEscape(id)+"/graph?format=dot", nil)
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	disposition, params, err := mime.ParseMediaType(w.Header().Get("Content-Disposition"))
+	if err != nil || disposition != "attachment" || params["filename"] != id+".dot" {
+		t.Errorf("Content-Disposition %q parsed as %q %v, %v", w.Header().Get("Content-Disposition"), disposition, params, err)
+	}
+}
+
+func TestMoleculesIncludeWithoutArchive(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	adapter := beads.NewCLIAdapter("")
+
+	server := NewServer(config, adapter)
+
+	req := httptest.NewRequest("GET", "/api/v1/town/molecules?include=completed", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+}
+
+func TestActiveWithin(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	since, until := now.Add(-day), time.Time{}
+
+	tests := []struct {
+		name string
+		mol  gastown.Molecule
+		want bool
+	}{
+		{"running", gastown.Molecule{Status: gastown.MolStatusInProgress, CreatedAt: now.Add(-7 * day)}, true},
+		{"finished in the window", gastown.Molecule{Status: gastown.MolStatusComplete, CreatedAt: now.Add(-2 * day), UpdatedAt: now.Add(-time.Hour)}, true},
+		{"finished before the window", gastown.Molecule{Status: gastown.MolStatusComplete, CreatedAt: now.Add(-7 * day), UpdatedAt: now.Add(-5 * day)}, false},
+		{"failed before the window", gastown.Molecule{Status: gastown.MolStatusFailed, UpdatedAt: now.Add(-2 * day)}, false},
+		{"finished without an update time", gastown.Molecule{Status: gastown.MolStatusComplete}, true},
+	}
+	for _, tt := range tests {
+		if got := activeWithin(tt.mol, now, since, until); got != tt.want {
+			t.Errorf("%s: activeWithin = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if activeWithin(gastown.Molecule{Status: gastown.MolStatusPending, CreatedAt: now}, now, time.Time{}, now.Add(-day)) {
+		t.Error("a molecule created after until must not be active")
+	}
+}
+
+func TestWorkIndexCached(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	server := NewServer(config, beads.NewCLIAdapter(""))
+
+	if server.work.Load() != nil {
+		t.Fatal("expected no work index before the first snapshot")
+	}
+	server.recordSnapshot()
+	cached := server.work.Load()
+	if cached == nil {
+		t.Fatal("expected the recorder to cache the work index")
+	}
+	if got := server.workIndex(context.Background()); got != cached {
+		t.Error("expected requests to use the recorder's work index")
+	}
+}
+
+func TestIssueTransitions(t *testing.T) {
+	lastObserved := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := lastObserved.Add(time.Minute)
+	changedAt := lastObserved.Add(20 * time.Second)
+
+	previous := model.IssueStates([]model.Issue{
+		{ID: "gvi-1", Status: model.StatusPending},
+		{ID: "gvi-2", Status: model.StatusInProgress},
+		{ID: "gvi-3", Status: model.StatusPending},
+	})
+	current := model.IssueStates([]model.Issue{
+		{ID: "gvi-1", Status: model.StatusInProgress, UpdatedAt: changedAt},
+		{ID: "gvi-2", Status: model.StatusInProgress, Title: "Renamed"},
+		{ID: "gvi-4", Title: "New", Status: model.StatusPending},
+	})
+
+	// The rename of gvi-2 is an update but not a status transition
+	transitions := issueTransitions(model.DiffIssueStates(previous, current), lastObserved, now)
+	if len(transitions) != 3 {
+		t.Fatalf("Expected 3 transitions, got %d: %+v", len(transitions), transitions)
+	}
+
+	if tr := transitions[0]; tr.IssueID != "gvi-1" || tr.From != model.StatusPending || !tr.Time.Equal(changedAt) {
+		t.Errorf("Unexpected update transition: %+v", tr)
+	}
+	if tr := transitions[1]; tr.IssueID != "gvi-4" || tr.From != "" || !tr.Time.Equal(now) {
+		t.Errorf("Unexpected created transition: %+v", tr)
+	}
+	if tr := transitions[2]; tr.IssueID != "gvi-3" || !tr.Deleted {
+		t.Errorf("Unexpected deleted transition: %+v", tr)
+	}
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	adapter := beads.NewCLIAdapter("")
+
+	server := NewServer(config, adapter)
+
+	req := httptest.NewRequest("GET", "/api/v1/history/board?since=7d&step=1h", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+}
+
+func TestViewsCRUD(t *testing.T) {
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	config.DataDir = t.TempDir()
+	adapter := beads.NewCLIAdapter("")
+
+	server := NewServer(config, adapter)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Host = "localhost:7070"
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("POST", "/api/v1/views", `{"name":"urgent","priority":["critical","high"],"sort":"-updated"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := do("POST", "/api/v1/views", `{"name":"urgent"}`); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for duplicate view, got %d", w.Code)
+	}
+	if w := do("POST", "/api/v1/views", `{"name":"bad","sort":"colour"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid sort, got %d", w.Code)
+	}
+	if w := do("POST", "/api/v1/views", `{"name":"typo","priorty":["high"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for unknown field, got %d", w.Code)
+	}
+
+	w = do("POST", "/api/v1/views", `{"name":"bad-query","query":"status:done colour:red"}`)
+	var errResp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if w.Code != http.StatusBadRequest || errResp.Details["offset"] != float64(12) {
+		t.Errorf("Expected 400 with error offset 12 for invalid query, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do("PUT", "/api/v1/views/urgent", `{"priority":["critical"],"group_by":"assignee"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do("GET", "/api/v1/views/urgent", "")
+	var view model.SavedView
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(view.Priority) != 1 || view.GroupBy != model.GroupByAssignee || view.Sort != "" {
+		t.Errorf("Expected view to be replaced, got %+v", view)
+	}
+
+	w = do("GET", "/api/v1/views", "")
+	var list ViewListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if list.Total != 1 {
+		t.Errorf("Expected 1 view, got %d", list.Total)
+	}
+
+	if w := do("DELETE", "/api/v1/views/urgent", ""); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+	if w := do("GET", "/api/v1/views/urgent", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestIssueWrites(t *testing.T) {
+	mock := beads.NewMockExecutor()
+	mock.SetResponse("status", []byte("ok"))
+	mock.SetResponse("show --json -- gt-1", []byte(`[{"id": "gt-1", "title": "Fix it", "status": "open", "priority": 2}]`))
+	mock.SetResponse("show --json -- gt-2", []byte(`[{"id": "gt-2", "title": "Prereq", "status": "open", "priority": 2}]`))
+	mock.SetError("show --json -- gt-9", &beads.NotFoundError{ID: "gt-9"})
+	mock.SetResponse("update --status in_progress --priority 1 -- gt-1", []byte(""))
+	mock.SetResponse("dep add --type blocks -- gt-1 gt-2", []byte(""))
+
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	config.AllowWrites = true
+	server := NewServer(config, beads.NewCLIAdapterWithExecutor("", mock))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Host = "localhost:7070"
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("PATCH", "/api/v1/issues/gt-1", `{"status":"in_progress","priority":"high"}`); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do("PATCH", "/api/v1/issues/gt-1", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for empty update, got %d", w.Code)
+	}
+	if w := do("PATCH", "/api/v1/issues/gt-1", `{"priority":"urgent"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid priority, got %d", w.Code)
+	}
+	if w := do("PATCH", "/api/v1/issues/gt-9", `{"title":"x"}`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown issue, got %d", w.Code)
+	}
+
+	if w := do("POST", "/api/v1/issues/gt-1/dependencies", `{"depends_on":"gt-2"}`); w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do("POST", "/api/v1/issues/gt-1/dependencies", `{"depends_on":"gt-1"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for self dependency, got %d", w.Code)
+	}
+	if w := do("POST", "/api/v1/issues/gt-1/dependencies", `{"depends_on":"gt-9"}`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown dependency, got %d", w.Code)
+	}
+
+	// IDs that bd would read as flags or split are rejected before bd runs
+	if w := do("PATCH", "/api/v1/issues/--db=x", `{"title":"x"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for flag-like ID, got %d", w.Code)
+	}
+	for _, body := range []string{`{"depends_on":"--help"}`, `{"depends_on":"gt-2 gt-3"}`} {
+		if w := do("POST", "/api/v1/issues/gt-1/dependencies", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+
+	for _, path := range []string{"/api/v1/issues/-h", "/api/v1/issues/--db=x/timeline", "/api/v1/issues/-h/tree"} {
+		if w := do("GET", path, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", path, w.Code)
+		}
+	}
+
+	server.config.AllowWrites = false
+	if w := do("PATCH", "/api/v1/issues/gt-1", `{"title":"x"}`); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 without AllowWrites, got %d", w.Code)
+	}
+}
+
+func TestListIssuesPaging(t *testing.T) {
+	mock := beads.NewMockExecutor()
+	mock.SetResponse("status", []byte("ok"))
+	mock.SetResponse("list --json", []byte(`[
+		{"id": "gt-1", "title": "One", "status": "open", "priority": 2},
+		{"id": "gt-2", "title": "Two", "status": "open", "priority": 2},
+		{"id": "gt-3", "title": "Three", "status": "open", "priority": 2}]`))
+
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	server := NewServer(config, beads.NewCLIAdapterWithExecutor("", mock))
+
+	list := func(params string) model.IssueListResponse {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/api/v1/issues"+params, nil)
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", params, w.Code, w.Body.String())
+		}
+		var resp model.IssueListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return resp
+	}
+
+	all := list("")
+	if len(all.Issues) != 3 || all.Total != 3 {
+		t.Fatalf("Expected 3 issues, got %d (total %d)", len(all.Issues), all.Total)
+	}
+
+	tests := []struct {
+		params string
+		want   []model.Issue
+	}{
+		{"?limit=2", all.Issues[:2]},
+		{"?offset=1", all.Issues[1:]},
+		{"?limit=1&offset=1", all.Issues[1:2]},
+		{"?offset=5", nil},
+	}
+	for _, tt := range tests {
+		resp := list(tt.params)
+		if resp.Total != 3 {
+			t.Errorf("%s: total %d, want 3", tt.params, resp.Total)
+		}
+		if len(resp.Issues) != len(tt.want) {
+			t.Errorf("%s: got %d issues, want %d", tt.params, len(resp.Issues), len(tt.want))
+			continue
+		}
+		for i := range tt.want {
+			if resp.Issues[i].ID != tt.want[i].ID {
+				t.Errorf("%s: issue %d is %s, want %s", tt.params, i, resp.Issues[i].ID, tt.want[i].ID)
+			}
+		}
+	}
+}
+
+func TestWriteGuard(t *testing.T) {
+	mock := beads.NewMockExecutor()
+	mock.SetResponse("status", []byte("ok"))
+	mock.SetResponse("show --json -- gt-1", []byte(`[{"id": "gt-1", "title": "Fix it", "status": "open", "priority": 2}]`))
+	mock.SetResponse("show --json -- gt-2", []byte(`[{"id": "gt-2", "title": "Prereq", "status": "open", "priority": 2}]`))
+	mock.SetResponse("dep add --type blocks -- gt-1 gt-2", []byte(""))
+
+	config := DefaultConfig()
+	config.TownRoot = "/tmp/nonexistent-town"
+	config.AllowWrites = true
+	server := NewServer(config, beads.NewCLIAdapterWithExecutor("", mock))
+
+	post := func(host, origin, contentType string) int {
+		req := httptest.NewRequest("POST", "/api/v1/issues/gt-1/dependencies", strings.NewReader(`{"depends_on":"gt-2"}`))
+		req.Host = host
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name, host, origin, contentType string
+		want                            int
+	}{
+		{"local client", "localhost:7070", "", "application/json", http.StatusCreated},
+		{"loopback alias", "127.0.0.1:7070", "", "application/json; charset=utf-8", http.StatusCreated},
+		{"gvid's own page", "localhost:7070", "http://localhost:7070", "application/json", http.StatusCreated},
+		{"allowed origin", "localhost:7070", "http://localhost:5173", "application/json", http.StatusCreated},
+		{"cross-site page", "localhost:7070", "https://evil.example", "application/json", http.StatusForbidden},
+		{"simple text/plain post", "localhost:7070", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"no content type", "localhost:7070", "", "", http.StatusUnsupportedMediaType},
+		{"DNS rebinding", "evil.example:7070", "http://evil.example:7070", "application/json", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if got := post(tt.host, tt.origin, tt.contentType); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// Reads are not guarded, and a server on every interface accepts any host
+	req := httptest.NewRequest("GET", "/api/v1/town/status", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("GET: status %d, want 200", w.Code)
+	}
+	server.config.Host = "0.0.0.0"
+	if got := post("gvid.lan:7070", "", "application/json"); got != http.StatusCreated {
+		t.Errorf("wildcard bind: status %d, want 201", got)
+	}
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// maxHistoryPoints caps the number of buckets a downsampled query may produce.
+const maxHistoryPoints = 5000
+
+// historyRange holds the parsed since/until/step parameters of a history query.
+type historyRange struct {
+	Since time.Time
+	Until time.Time
+	Step  time.Duration
+}
+
+// parseHistoryRange reads since, until and step. Since defaults to 24 hours
+// ago and until to now.
+func parseHistoryRange(query url.Values, now time.Time) (historyRange, error) {
+	since, until, err := parseTimeRange(query, now)
+	if err != nil {
+		return historyRange{}, err
+	}
+	if since.IsZero() {
+		since = now.Add(-24 * time.Hour)
+	}
+	if until.IsZero() {
+		until = now
+	}
+
+	hr := historyRange{Since: since, Until: until}
+	if stepStr := query.Get("step"); stepStr != "" {
+		step, err := model.ParseDuration(stepStr)
+		if err != nil || step <= 0 {
+			return historyRange{}, errInvalidStep
+		}
+		if until.Sub(since)/step > maxHistoryPoints {
+			return historyRange{}, errTooManyPoints
+		}
+		hr.Step = step
+	}
+	return hr, nil
+}
+
+var (
+	errInvalidStep   = errors.New("step must be a positive duration such as 5m, 1h or 1d")
+	errTooManyPoints = errors.New("step is too small for the requested range")
+)
+
+// requireHistory writes an error and returns false if the history store is disabled.
+func (s *Server) requireHistory(w http.ResponseWriter) bool {
+	if s.history == nil {
+		writeError(w, http.StatusServiceUnavailable, "HISTORY_DISABLED",
+			"History store is disabled. Start gvid with -data to enable it.")
+		return false
+	}
+	return true
+}
+
+// handleHistoryBoard handles GET /api/v1/history/board.
+func (s *Server) handleHistoryBoard(w http.ResponseWriter, r *http.Request) {
+	if !s.requireHistory(w) {
+		return
+	}
+
+	hr, err := parseHistoryRange(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	samples, err := s.history.BoardSeries(hr.Since, hr.Until, hr.Step)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "HISTORY_ERROR", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"samples": nonNil(samples),
+		"since":   hr.Since,
+		"until":   hr.Until,
+		"step":    hr.Step.String(),
+	})
+}
+
+// handleHistoryAgents handles GET /api/v1/history/agents.
+// The optional address parameter limits samples to one agent.
+func (s *Server) handleHistoryAgents(w http.ResponseWriter, r *http.Request) {
+	if !s.requireHistory(w) {
+		return
+	}
+
+	query := r.URL.Query()
+	hr, err := parseHistoryRange(query, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	samples, err := s.history.AgentSeries(hr.Since, hr.Until, hr.Step)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "HISTORY_ERROR", err.Error())
+		return
+	}
+
+	if address := query.Get("address"); address != "" {
+		for i := range samples {
+			var agents []store.AgentState
+			for _, a := range samples[i].Data {
+				if a.Address == address {
+					agents = append(agents, a)
+				}
+			}
+			samples[i].Data = agents
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"samples": nonNil(samples),
+		"since":   hr.Since,
+		"until":   hr.Until,
+		"step":    hr.Step.String(),
+	})
+}
+
+// handleHistoryConvoys handles GET /api/v1/history/convoys.
+// The optional convoy parameter limits samples to one convoy.
+func (s *Server) handleHistoryConvoys(w http.ResponseWriter, r *http.Request) {
+	if !s.requireHistory(w) {
+		return
+	}
+
+	query := r.URL.Query()
+	hr, err := parseHistoryRange(query, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	samples, err := s.history.ConvoySeries(hr.Since, hr.Until, hr.Step)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "HISTORY_ERROR", err.Error())
+		return
+	}
+
+	if id := query.Get("convoy"); id != "" {
+		for i := range samples {
+			var convoys []store.ConvoyState
+			for _, c := range samples[i].Data {
+				if c.ID == id {
+					convoys = append(convoys, c)
+				}
+			}
+			samples[i].Data = convoys
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"samples": nonNil(samples),
+		"since":   hr.Since,
+		"until":   hr.Until,
+		"step":    hr.Step.String(),
+	})
+}
+
+// handleHistoryTransitions handles GET /api/v1/history/transitions.
+// The optional issue parameter limits results to one issue.
+func (s *Server) handleHistoryTransitions(w http.ResponseWriter, r *http.Request) {
+	if !s.requireHistory(w) {
+		return
+	}
+
+	query := r.URL.Query()
+	hr, err := parseHistoryRange(query, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	transitions, err := s.history.Transitions(store.TransitionQuery{
+		IssueID: query.Get("issue"),
+		Since:   hr.Since,
+		Until:   hr.Until,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "HISTORY_ERROR", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"transitions": nonNil(transitions),
+		"total":       len(transitions),
+		"since":       hr.Since,
+		"until":       hr.Until,
+	})
+}
+
+// handleHistorySnapshot handles GET /api/v1/history/snapshot?at=.
+// It reconstructs the board, agents, convoys and issue statuses as
+// recorded at the given instant.
+func (s *Server) handleHistorySnapshot(w http.ResponseWriter, r *http.Request) {
+	if !s.requireHistory(w) {
+		return
+	}
+
+	now := time.Now()
+	at, err := parseTimeParam(r.URL.Query().Get("at"), now)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+	if at.IsZero() {
+		at = now
+	}
+
+	snap, err := s.history.At(at)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "HISTORY_ERROR", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// nonNil returns an empty slice instead of nil so responses encode as [].
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/metrics"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// handleIssueTimeline handles GET /api/v1/issues/{id}/timeline.
+func (s *Server) handleIssueTimeline(w http.ResponseWriter, r *http.Request) {
+	if !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+	id := r.PathValue("id")
+	if err := model.ValidateIssueID(id); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	issue, err := s.adapter.GetIssue(ctx, id)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	entries, source := s.issueStatusEntries(ctx, id)
+	writeJSON(w, http.StatusOK, metrics.BuildTimeline(*issue, entries, source, time.Now()))
+}
+
+// defaultAgingThreshold is how long an issue may stay in progress before
+// the flow report lists it as aging.
+const defaultAgingThreshold = 3 * 24 * time.Hour
+
+// handleFlowMetrics handles GET /api/v1/metrics/flow.
+func (s *Server) handleFlowMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+	query := r.URL.Query()
+	now := time.Now()
+
+	filter := model.NewIssueFilter()
+	if err := parseIssueFilterParams(query, &filter); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	since, until, err := parseTimeRange(query, now)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+	if since.IsZero() {
+		since = now.AddDate(0, 0, -30)
+	}
+	if until.IsZero() {
+		until = now
+	}
+
+	opts := metrics.FlowOptions{Since: since, Until: until, AgingThreshold: defaultAgingThreshold}
+	if agingStr := query.Get("aging"); agingStr != "" {
+		aging, err := model.ParseDuration(agingStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+			return
+		}
+		opts.AgingThreshold = aging
+	}
+
+	issues, err := s.adapter.ListIssues(ctx, filter)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, metrics.ComputeFlow(issues, s.startTimes(), opts, now))
+}
+
+// startTimes returns when each issue first moved to in_progress according
+// to the recorded transitions. Without the history store it is empty, and
+// cycle times are not reported.
+func (s *Server) startTimes() map[string]time.Time {
+	started := make(map[string]time.Time)
+	if s.history == nil {
+		return started
+	}
+
+	transitions, err := s.history.Transitions(store.TransitionQuery{})
+	if err != nil {
+		return started
+	}
+	for _, t := range transitions {
+		if t.To != model.StatusInProgress {
+			continue
+		}
+		if first, ok := started[t.IssueID]; !ok || t.Time.Before(first) {
+			started[t.IssueID] = t.Time
+		}
+	}
+	return started
+}
+
+// handleIssueTree handles GET /api/v1/issues/{id}/tree.
+func (s *Server) handleIssueTree(w http.ResponseWriter, r *http.Request) {
+	if !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := model.ValidateIssueID(id); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	issues, err := s.adapter.ListIssues(r.Context(), model.NewIssueFilter())
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	tree, ok := metrics.BuildHierarchy(issues).Tree(id, time.Now())
+	if !ok {
+		handleAdapterError(w, &beads.NotFoundError{ID: id})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tree)
+}
+
+// handleEpics handles GET /api/v1/epics.
+func (s *Server) handleEpics(w http.ResponseWriter, r *http.Request) {
+	if !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	issues, err := s.adapter.ListIssues(r.Context(), model.NewIssueFilter())
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	epics := metrics.BuildHierarchy(issues).Epics(time.Now())
+	writeJSON(w, http.StatusOK, model.EpicListResponse{Epics: epics, Total: len(epics)})
+}
+
+// issueStatusEntries returns an issue's status changes from bd's event log
+// when the installed bd provides one, otherwise from the transitions gvid
+// has recorded. With neither, the timeline is derived from the issue's
+// timestamps alone.
+func (s *Server) issueStatusEntries(ctx context.Context, id string) ([]model.TimelineEntry, model.TimelineSource) {
+	if entries, err := s.adapter.IssueEvents(ctx, id); err == nil && len(entries) > 0 {
+		return entries, model.TimelineSourceBD
+	}
+
+	if s.history != nil {
+		transitions, err := s.history.Transitions(store.TransitionQuery{IssueID: id})
+		if err == nil && len(transitions) > 0 {
+			return transitionEntries(transitions), model.TimelineSourceGVID
+		}
+	}
+
+	return nil, model.TimelineSourceIssue
+}
+
+// transitionEntries converts recorded transitions to timeline entries.
+func transitionEntries(transitions []store.IssueTransition) []model.TimelineEntry {
+	entries := make([]model.TimelineEntry, 0, len(transitions))
+	for _, t := range transitions {
+		if t.Deleted {
+			continue
+		}
+		entries = append(entries, model.TimelineEntry{
+			Time:   t.Time,
+			From:   t.From,
+			To:     t.To,
+			Source: model.TimelineSourceGVID,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// parseTimeParam parses a time query parameter. It accepts RFC 3339
+// timestamps, plain dates (2006-01-02, local time) and relative durations
+// such as "90m", "24h" or "7d", which are measured back from now.
+func parseTimeParam(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := model.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339, YYYY-MM-DD or a duration like 24h or 7d", value)
+}
+
+// parseTimeRange reads the since and until query parameters.
+func parseTimeRange(query url.Values, now time.Time) (since, until time.Time, err error) {
+	if since, err = parseTimeParam(query.Get("since"), now); err != nil {
+		return
+	}
+	if until, err = parseTimeParam(query.Get("until"), now); err != nil {
+		return
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		err = fmt.Errorf("until must not be before since")
+	}
+	return
+}
+
+// parseListParam splits a comma-separated query parameter, dropping empty
+// values.
+func parseListParam(query url.Values, key string) []string {
+	var values []string
+	for _, v := range strings.Split(query.Get(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseIssueFilterParams reads the priority, type and unmet_criteria query
+// parameters into filter, replacing any values already set. Priority and
+// type accept comma-separated lists.
+func parseIssueFilterParams(query url.Values, filter *model.IssueFilter) error {
+	if values := parseListParam(query, "priority"); len(values) > 0 {
+		filter.Priority = nil
+		for _, p := range values {
+			priority, ok := model.ParsePriority(p)
+			if !ok {
+				return fmt.Errorf("invalid priority %q: use critical, high, medium, low, backlog or P0-P4", p)
+			}
+			filter.Priority = append(filter.Priority, priority)
+		}
+	}
+	if values := parseListParam(query, "type"); len(values) > 0 {
+		filter.Type = values
+	}
+
+	if v := query.Get("unmet_criteria"); v != "" {
+		unmet, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid unmet_criteria %q: use true or false", v)
+		}
+		filter.UnmetCriteria = unmet
+	}
+	return nil
+}
+
+// parseRenderParam reads the render query parameter, which requests
+// rendered descriptions. The only supported format is html.
+func parseRenderParam(query url.Values) (bool, error) {
+	switch v := query.Get("render"); v {
+	case "":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid render %q: use html", v)
+	}
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/join"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// openStores opens the local archive under Config.DataDir. Failures are
+// logged and leave the corresponding store disabled so the daemon still
+// serves live data.
+func (s *Server) openStores() {
+	if s.config.DataDir == "" {
+		return
+	}
+
+	molecules, err := store.OpenMoleculeArchive(s.config.DataDir)
+	if err != nil {
+		log.Printf("Molecule archive disabled: %v", err)
+	} else {
+		s.molecules = molecules
+	}
+
+	history, err := store.OpenHistory(s.config.DataDir)
+	if err != nil {
+		log.Printf("History store disabled: %v", err)
+	} else {
+		s.history = history
+		// Seed the baseline so status changes made while gvid was down
+		// are still recorded.
+		s.issueStates = make(map[string]model.IssueState)
+		for id, status := range history.Statuses() {
+			s.issueStates[id] = model.IssueState{Status: status}
+		}
+	}
+
+	views, err := store.OpenViewStore(s.config.DataDir)
+	if err != nil {
+		log.Printf("Saved views disabled: %v", err)
+	} else {
+		s.views = views
+	}
+}
+
+// runRecorder periodically snapshots beads and Gas Town state into the
+// local archive until the server shuts down. Issue changes found between
+// snapshots are also broadcast to SSE clients.
+func (s *Server) runRecorder() {
+	if s.config.SnapshotInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.SnapshotInterval)
+	defer ticker.Stop()
+
+	s.recordSnapshot()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.recordSnapshot()
+		}
+	}
+}
+
+// recordSnapshot takes one snapshot of every recorded source.
+func (s *Server) recordSnapshot() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.SnapshotInterval)
+	defer cancel()
+
+	now := time.Now()
+
+	if issues, err := s.adapter.ListIssues(ctx, model.NewIssueFilter()); err == nil {
+		s.refs.setIssues(issues)
+		s.observeIssues(issues, now)
+	}
+
+	// Gas Town is optional, so read errors leave that part of the work
+	// index empty
+	agents, agentsErr := s.gtAdapter.Agents(ctx)
+	convoys, convoysErr := s.gtAdapter.Convoys(ctx)
+	molecules, moleculesErr := s.gtAdapter.Molecules(ctx)
+	s.work.Store(join.New(agents, convoys, molecules))
+
+	if moleculesErr == nil {
+		ids := make([]string, len(molecules))
+		for i, m := range molecules {
+			ids[i] = m.ID
+		}
+		s.refs.set(refMolecule, ids)
+		s.archiveMolecules(molecules)
+	}
+
+	if convoysErr == nil {
+		ids := make([]string, len(convoys))
+		for i, c := range convoys {
+			ids[i] = c.ID
+		}
+		s.refs.set(refConvoy, ids)
+	}
+
+	if s.history == nil {
+		return
+	}
+
+	if agentsErr == nil {
+		states := make([]store.AgentState, 0, len(agents))
+		for _, a := range agents {
+			states = append(states, store.AgentState{
+				Address:  a.Address(),
+				Role:     string(a.Role),
+				Rig:      a.Rig,
+				Status:   string(a.Status),
+				Molecule: a.Molecule,
+			})
+		}
+		if err := s.history.RecordAgents(states, now); err != nil {
+			log.Printf("History write failed: %v", err)
+		}
+	}
+
+	if convoysErr == nil {
+		states := make([]store.ConvoyState, 0, len(convoys))
+		for _, c := range convoys {
+			states = append(states, store.ConvoyState{
+				ID:        c.ID,
+				Title:     c.Title,
+				Status:    string(c.Status),
+				Completed: c.Completed,
+				Total:     c.Total,
+				Progress:  c.Progress,
+			})
+		}
+		if err := s.history.RecordConvoys(states, now); err != nil {
+			log.Printf("History write failed: %v", err)
+		}
+	}
+}
+
+// observeIssues compares issues with the previous observation, records the
+// resulting status transitions and board counts, and notifies SSE clients.
+// Nothing is broadcast for the first observation after startup, which only
+// establishes the baseline.
+func (s *Server) observeIssues(issues []model.Issue, now time.Time) {
+	current := model.IssueStates(issues)
+	changes := model.DiffIssueStates(s.issueStates, current)
+	s.issueStates = current
+
+	if s.history != nil {
+		if err := s.history.RecordTransitions(issueTransitions(changes, s.lastObserved, now)); err != nil {
+			log.Printf("History write failed: %v", err)
+		}
+		if err := s.history.RecordBoard(issues, now); err != nil {
+			log.Printf("History write failed: %v", err)
+		}
+	}
+
+	baseline := s.lastObserved.IsZero()
+	s.lastObserved = now
+	if baseline {
+		return
+	}
+
+	for _, c := range changes {
+		switch c.Type {
+		case model.EventTypeIssueDeleted:
+			s.NotifyIssueDeleted(c.ID)
+		case model.EventTypeIssueCreated:
+			s.NotifyIssueCreated(c.ID, c.After.Title, c.After.Status)
+		default:
+			s.NotifyIssueUpdated(c.ID, c.After.Status, c.Before.Status)
+		}
+	}
+}
+
+// issueTransitions returns the status transitions among changes. A change is
+// dated by the issue's UpdatedAt when that falls between the two
+// observations, otherwise by now.
+func issueTransitions(changes []model.IssueChange, lastObserved, now time.Time) []store.IssueTransition {
+	var transitions []store.IssueTransition
+	for _, c := range changes {
+		if c.Type == model.EventTypeIssueDeleted {
+			transitions = append(transitions, store.IssueTransition{
+				Time:    now,
+				IssueID: c.ID,
+				From:    c.Before.Status,
+				Deleted: true,
+			})
+			continue
+		}
+		if c.Before.Status == c.After.Status {
+			continue
+		}
+
+		at := now
+		if c.After.UpdatedAt.After(lastObserved) && !c.After.UpdatedAt.After(now) {
+			at = c.After.UpdatedAt
+		}
+		transitions = append(transitions, store.IssueTransition{
+			Time:    at,
+			IssueID: c.ID,
+			Title:   c.After.Title,
+			From:    c.Before.Status,
+			To:      c.After.Status,
+		})
+	}
+	return transitions
+}
+
+// archiveMolecules records observed molecules, if the archive is enabled.
+func (s *Server) archiveMolecules(molecules []gastown.Molecule) {
+	if s.molecules == nil {
+		return
+	}
+	if err := s.molecules.Record(molecules, time.Now()); err != nil {
+		log.Printf("Molecule archive write failed: %v", err)
+	}
+}
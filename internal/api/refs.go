@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/markdown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Kinds of IDs that descriptions can link to, and their link targets.
+const (
+	refIssue    = "issue"
+	refConvoy   = "convoy"
+	refMolecule = "molecule"
+)
+
+var refPaths = map[string]string{
+	refIssue:    "/issues/",
+	refConvoy:   "/convoys/",
+	refMolecule: "/molecules/",
+}
+
+// refIndex remembers the issue, convoy and molecule IDs the recorder has
+// seen, so rendered descriptions can link the IDs they mention.
+type refIndex struct {
+	mu       sync.RWMutex
+	ids      map[string]map[string]bool // kind -> IDs
+	prefixes map[string]bool            // rig prefixes of known issues
+}
+
+func newRefIndex() *refIndex {
+	return &refIndex{
+		ids:      make(map[string]map[string]bool),
+		prefixes: make(map[string]bool),
+	}
+}
+
+// set replaces the known IDs of one kind.
+func (r *refIndex) set(kind string, ids []string) {
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids[kind] = known
+	if kind == refIssue {
+		r.prefixes = make(map[string]bool)
+		for _, id := range ids {
+			r.prefixes[model.IssueRig(id)] = true
+		}
+	}
+}
+
+// setIssues records the IDs of issues.
+func (r *refIndex) setIssues(issues []model.Issue) {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	r.set(refIssue, ids)
+}
+
+// linker returns a markdown.Linker for known IDs. Tokens sharing the rig
+// prefix of a known issue or of one of the context issues are linked as
+// issues even before the recorder has seen them.
+func (r *refIndex) linker(context ...model.Issue) markdown.Linker {
+	prefixes := make(map[string]bool)
+	for _, issue := range context {
+		prefixes[model.IssueRig(issue.ID)] = true
+	}
+
+	return func(id string) (string, bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		for _, kind := range []string{refIssue, refConvoy, refMolecule} {
+			if r.ids[kind][id] {
+				return refPaths[kind] + url.PathEscape(id), true
+			}
+		}
+		if rig := model.IssueRig(id); rig != "" && (prefixes[rig] || r.prefixes[rig]) {
+			return refPaths[refIssue] + url.PathEscape(id), true
+		}
+		return "", false
+	}
+}
@@ -5,11 +5,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"mime"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/join"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
 )
 
 // Config holds server configuration.
@@ -19,6 +27,15 @@ type Config struct {
 	CORSOrigins []string
 	Version     string
 	TownRoot    string // Gas Town workspace root (default: ~/gt)
+
+	// DataDir holds gvid's local archive. Empty disables persistence.
+	DataDir string
+	// SnapshotInterval is how often the recorder polls beads and Gas Town.
+	SnapshotInterval time.Duration
+	// Board defines the columns of the board view.
+	Board model.BoardConfig
+	// AllowWrites lets API requests change issues. It is off by default.
+	AllowWrites bool
 }
 
 // DefaultConfig returns configuration with sensible defaults.
@@ -29,6 +46,9 @@ func DefaultConfig() Config {
 		CORSOrigins: []string{"http://localhost:5173"},
 		Version:     "0.1.0",
 		TownRoot:    "", // Empty means use default ~/gt
+
+		SnapshotInterval: time.Minute,
+		Board:            model.DefaultBoardConfig(),
 	}
 }
 
@@ -39,17 +59,34 @@ type Server struct {
 	gtAdapter gastown.Adapter
 	mux       *http.ServeMux
 	sse       *SSEBroker
+	molecules *store.MoleculeArchive // nil when persistence is disabled
+	history   *store.History         // nil when persistence is disabled
+	views     *store.ViewStore       // nil when persistence is disabled
+	refs      *refIndex
+	work      atomic.Pointer[join.Index] // set by the recorder
+	stop      chan struct{}
+
+	// Recorder state, owned by the recorder goroutine
+	issueStates  map[string]model.IssueState
+	lastObserved time.Time
 }
 
 // NewServer creates a new API server.
 func NewServer(config Config, adapter beads.Adapter) *Server {
+	if len(config.Board.Columns) == 0 {
+		config.Board = model.DefaultBoardConfig()
+	}
+
 	s := &Server{
 		config:    config,
 		adapter:   adapter,
 		gtAdapter: gastown.NewFSAdapter(config.TownRoot),
 		mux:       http.NewServeMux(),
 		sse:       NewSSEBroker(),
+		refs:      newRefIndex(),
+		stop:      make(chan struct{}),
 	}
+	s.openStores()
 	s.registerRoutes()
 	return s
 }
@@ -62,6 +99,21 @@ func (s *Server) registerRoutes() {
 	// Beads - Issues
 	s.mux.HandleFunc("GET /api/v1/issues", s.handleListIssues)
 	s.mux.HandleFunc("GET /api/v1/issues/{id}", s.handleGetIssue)
+	s.mux.HandleFunc("PATCH /api/v1/issues/{id}", s.handleUpdateIssue)
+	s.mux.HandleFunc("POST /api/v1/issues/{id}/dependencies", s.handleAddDependency)
+	s.mux.HandleFunc("GET /api/v1/issues/{id}/timeline", s.handleIssueTimeline)
+	s.mux.HandleFunc("GET /api/v1/issues/{id}/tree", s.handleIssueTree)
+	s.mux.HandleFunc("GET /api/v1/epics", s.handleEpics)
+
+	// Beads - Export
+	s.mux.HandleFunc("GET /api/v1/export", s.handleExport)
+
+	// Saved views
+	s.mux.HandleFunc("GET /api/v1/views", s.handleListViews)
+	s.mux.HandleFunc("POST /api/v1/views", s.handleCreateView)
+	s.mux.HandleFunc("GET /api/v1/views/{name}", s.handleGetView)
+	s.mux.HandleFunc("PUT /api/v1/views/{name}", s.handlePutView)
+	s.mux.HandleFunc("DELETE /api/v1/views/{name}", s.handleDeleteView)
 
 	// Beads - Board
 	s.mux.HandleFunc("GET /api/v1/board", s.handleBoard)
@@ -69,6 +121,9 @@ func (s *Server) registerRoutes() {
 	// Beads - Graph
 	s.mux.HandleFunc("GET /api/v1/graph", s.handleGraph)
 
+	// Beads - Metrics
+	s.mux.HandleFunc("GET /api/v1/metrics/flow", s.handleFlowMetrics)
+
 	// SSE Events
 	s.mux.HandleFunc("GET /api/v1/events", s.handleEvents)
 
@@ -90,17 +145,26 @@ func (s *Server) registerRoutes() {
 	// Gas Town - Molecules
 	s.mux.HandleFunc("GET /api/v1/town/molecules", s.handleMolecules)
 	s.mux.HandleFunc("GET /api/v1/town/molecules/{id}", s.handleMolecule)
+	s.mux.HandleFunc("GET /api/v1/town/molecules/{id}/graph", s.handleMoleculeGraph)
+	s.mux.HandleFunc("GET /api/v1/town/formulas/stats", s.handleFormulaStats)
 
 	// Gas Town - Mail
 	s.mux.HandleFunc("GET /api/v1/town/mail/{address}", s.handleMail)
 
+	// History
+	s.mux.HandleFunc("GET /api/v1/history/board", s.handleHistoryBoard)
+	s.mux.HandleFunc("GET /api/v1/history/agents", s.handleHistoryAgents)
+	s.mux.HandleFunc("GET /api/v1/history/convoys", s.handleHistoryConvoys)
+	s.mux.HandleFunc("GET /api/v1/history/transitions", s.handleHistoryTransitions)
+	s.mux.HandleFunc("GET /api/v1/history/snapshot", s.handleHistorySnapshot)
+
 	// Static files — catch-all after API routes
 	s.serveStaticFiles()
 }
 
 // Handler returns the HTTP handler with middleware applied.
 func (s *Server) Handler() http.Handler {
-	return s.corsMiddleware(s.loggingMiddleware(s.mux))
+	return s.corsMiddleware(s.loggingMiddleware(s.guardWrites(s.mux)))
 }
 
 // Start starts the HTTP server.
@@ -112,6 +176,9 @@ func (s *Server) Start() error {
 	// Start SSE broker
 	go s.sse.Start()
 
+	// Start snapshot recorder
+	go s.runRecorder()
+
 	server := &http.Server{
 		Addr:         addr,
 		Handler:      s.Handler(),
@@ -128,18 +195,9 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 
-		// Check if origin is allowed
-		allowed := false
-		for _, o := range s.config.CORSOrigins {
-			if o == origin || o == "*" {
-				allowed = true
-				break
-			}
-		}
-
-		if allowed {
+		if s.allowedOrigin(origin) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
-			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 		}
 
@@ -153,6 +211,82 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// allowedOrigin reports whether origin is one of the CORS origins.
+func (s *Server) allowedOrigin(origin string) bool {
+	for _, o := range s.config.CORSOrigins {
+		if o == origin || o == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// guardWrites rejects requests that change issues or views unless they are
+// addressed to gvid's listen address, come from gvid's own page or an
+// allowed origin, and carry a JSON body. Browsers send cross-site form and
+// text/plain posts without a preflight, so CORS headers alone would let
+// any web page, or a DNS-rebound one, change the user's beads.
+func (s *Server) guardWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, "FORBIDDEN_HOST",
+				fmt.Sprintf("Host %q does not match gvid's listen address.", r.Host))
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !s.allowedOrigin(origin) && !sameOrigin(origin, r.Host) {
+			writeError(w, http.StatusForbidden, "FORBIDDEN_ORIGIN",
+				fmt.Sprintf("Origin %q is not allowed to make changes.", origin))
+			return
+		}
+		if r.Method != http.MethodDelete {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+					"Request body must be sent as application/json.")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether a request's Host header names the address
+// gvid listens on. Loopback names are interchangeable, and a server bound
+// to every interface accepts any host.
+func (s *Server) allowedHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+
+	listen := s.config.Host
+	if ip := net.ParseIP(listen); listen == "" || (ip != nil && ip.IsUnspecified()) {
+		return true
+	}
+	return strings.EqualFold(host, listen) || (isLoopback(listen) && isLoopback(host))
+}
+
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sameOrigin reports whether origin is the page gvid itself serves at host.
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, host)
+}
+
 // loggingMiddleware logs requests.
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -182,8 +316,13 @@ func (s *Server) checkBeadsInitialized(w http.ResponseWriter, r *http.Request) b
 	return true
 }
 
-// Shutdown gracefully shuts down the server.
+// Shutdown gracefully shuts down the server and writes out state the
+// recorder keeps in memory.
 func (s *Server) Shutdown(ctx context.Context) error {
+	close(s.stop)
 	s.sse.Stop()
+	if s.molecules != nil {
+		return s.molecules.Flush()
+	}
 	return nil
 }
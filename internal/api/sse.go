@@ -124,6 +124,9 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The stream outlives the server's write timeout, so lift it here
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -163,3 +166,8 @@ func (s *Server) NotifyIssueCreated(id, title string, status model.Status) {
 func (s *Server) NotifyIssueUpdated(id string, status, previousStatus model.Status) {
 	s.sse.Broadcast(model.NewIssueUpdatedEvent(id, status, previousStatus))
 }
+
+// NotifyIssueDeleted broadcasts an issue_deleted event.
+func (s *Server) NotifyIssueDeleted(id string) {
+	s.sse.Broadcast(model.NewIssueDeletedEvent(id))
+}
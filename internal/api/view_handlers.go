@@ -0,0 +1,253 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/query"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// maxViewBody caps the size of a saved view request body.
+const maxViewBody = 64 << 10
+
+// ViewListResponse is the response for GET /api/v1/views.
+type ViewListResponse struct {
+	Views []model.SavedView `json:"views"`
+	Total int               `json:"total"`
+}
+
+// requireViews writes an error and returns false if the view store is disabled.
+func (s *Server) requireViews(w http.ResponseWriter) bool {
+	if s.views == nil {
+		writeError(w, http.StatusServiceUnavailable, "VIEWS_DISABLED",
+			"Saved views are disabled. Start gvid with -data to enable them.")
+		return false
+	}
+	return true
+}
+
+// handleListViews handles GET /api/v1/views.
+func (s *Server) handleListViews(w http.ResponseWriter, r *http.Request) {
+	if !s.requireViews(w) {
+		return
+	}
+
+	views := s.views.List()
+	writeJSON(w, http.StatusOK, ViewListResponse{Views: views, Total: len(views)})
+}
+
+// handleGetView handles GET /api/v1/views/{name}.
+func (s *Server) handleGetView(w http.ResponseWriter, r *http.Request) {
+	if !s.requireViews(w) {
+		return
+	}
+
+	view, err := s.views.Get(r.PathValue("name"))
+	if err != nil {
+		handleViewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+// handleCreateView handles POST /api/v1/views.
+func (s *Server) handleCreateView(w http.ResponseWriter, r *http.Request) {
+	if !s.requireViews(w) {
+		return
+	}
+
+	view, ok := decodeView(w, r)
+	if !ok {
+		return
+	}
+	if !validateView(w, view) {
+		return
+	}
+
+	view, err := s.views.Create(view, time.Now())
+	if err != nil {
+		handleViewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, view)
+}
+
+// handlePutView handles PUT /api/v1/views/{name}.
+func (s *Server) handlePutView(w http.ResponseWriter, r *http.Request) {
+	if !s.requireViews(w) {
+		return
+	}
+
+	view, ok := decodeView(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("name")
+	if view.Name != "" && view.Name != name {
+		writeError(w, http.StatusBadRequest, "INVALID_VIEW", "view name in body does not match the URL")
+		return
+	}
+	view.Name = name
+	if !validateView(w, view) {
+		return
+	}
+
+	view, err := s.views.Put(view, time.Now())
+	if err != nil {
+		handleViewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+// handleDeleteView handles DELETE /api/v1/views/{name}.
+func (s *Server) handleDeleteView(w http.ResponseWriter, r *http.Request) {
+	if !s.requireViews(w) {
+		return
+	}
+
+	if err := s.views.Delete(r.PathValue("name")); err != nil {
+		handleViewError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeView reads a saved view from the request body, rejecting unknown
+// fields so typos in filter names are not silently ignored. The name is
+// validated by the caller, since PUT takes it from the URL.
+func decodeView(w http.ResponseWriter, r *http.Request) (model.SavedView, bool) {
+	var view model.SavedView
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxViewBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&view); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_VIEW", "invalid view: "+err.Error())
+		return model.SavedView{}, false
+	}
+	return view, true
+}
+
+// validateView checks a view, including its query, writing an error and
+// returning false if it is invalid.
+func validateView(w http.ResponseWriter, view model.SavedView) bool {
+	if err := view.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_VIEW", err.Error())
+		return false
+	}
+	if _, err := query.Parse(view.Query); err != nil {
+		writeQueryError(w, "INVALID_VIEW", err)
+		return false
+	}
+	return true
+}
+
+// writeQueryError writes a query syntax error with its position.
+func writeQueryError(w http.ResponseWriter, code string, err error) {
+	resp := ErrorResponse{Error: err.Error(), Code: code}
+	var qerr *query.Error
+	if errors.As(err, &qerr) {
+		resp.Details = map[string]interface{}{
+			"offset":  qerr.Offset,
+			"length":  qerr.Length,
+			"message": qerr.Message,
+		}
+	}
+	writeJSON(w, http.StatusBadRequest, resp)
+}
+
+// handleViewError converts view store errors to HTTP responses.
+func handleViewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrViewNotFound):
+		writeError(w, http.StatusNotFound, "VIEW_NOT_FOUND", err.Error())
+	case errors.Is(err, store.ErrViewExists):
+		writeError(w, http.StatusConflict, "VIEW_EXISTS", err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "STORE_ERROR", err.Error())
+	}
+}
+
+// issueQuery is the filter, sort and grouping requested for an issue
+// listing, board or graph.
+type issueQuery struct {
+	Filter  model.IssueFilter
+	Query   *query.Query
+	Sort    string
+	GroupBy model.GroupBy
+	// Filtered is true when the request narrows the set of issues.
+	Filtered bool
+}
+
+// parseIssueQuery starts from the saved view named by ?view=, if any, and
+// applies the explicit query parameters on top of it; ?q= replaces the
+// view's query. It writes an error
+// and returns false for an unknown view or invalid parameter.
+func (s *Server) parseIssueQuery(w http.ResponseWriter, r *http.Request) (issueQuery, bool) {
+	params := r.URL.Query()
+	q := issueQuery{Filter: model.NewIssueFilter()}
+
+	if name := params.Get("view"); name != "" {
+		if !s.requireViews(w) {
+			return issueQuery{}, false
+		}
+		view, err := s.views.Get(name)
+		if err != nil {
+			handleViewError(w, err)
+			return issueQuery{}, false
+		}
+		q = issueQuery{Filter: view.Filter(), Sort: view.Sort, GroupBy: view.GroupBy, Filtered: true}
+		// Views are validated when saved
+		q.Query, _ = query.Parse(view.Query)
+	}
+
+	if v := params.Get("q"); v != "" {
+		parsed, err := query.Parse(v)
+		if err != nil {
+			writeQueryError(w, "INVALID_QUERY", err)
+			return issueQuery{}, false
+		}
+		q.Query = parsed
+	}
+
+	for _, key := range []string{"q", "status", "parent", "search", "priority", "type", "unmet_criteria"} {
+		if params.Get(key) != "" {
+			q.Filtered = true
+		}
+	}
+	if v := params.Get("status"); v != "" {
+		q.Filter.Status = v
+	}
+	if v := params.Get("parent"); v != "" {
+		q.Filter.Parent = v
+	}
+	if v := params.Get("search"); v != "" {
+		q.Filter.Search = v
+	}
+	if err := parseIssueFilterParams(params, &q.Filter); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return issueQuery{}, false
+	}
+
+	if v := params.Get("sort"); v != "" {
+		if err := model.ValidateSort(v); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+			return issueQuery{}, false
+		}
+		q.Sort = v
+	}
+	if v := params.Get("group_by"); v != "" {
+		groupBy, err := model.ParseGroupBy(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+			return issueQuery{}, false
+		}
+		q.GroupBy = groupBy
+	}
+
+	return q, true
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// maxWriteBody caps the size of an issue update request body.
+const maxWriteBody = 1 << 20
+
+// requireWritable writes an error and returns false unless gvid was started
+// with writes allowed.
+func (s *Server) requireWritable(w http.ResponseWriter) bool {
+	if !s.config.AllowWrites {
+		writeError(w, http.StatusForbidden, "READ_ONLY",
+			"gvid is running read-only. Restart it with -allow-writes to allow changes.")
+		return false
+	}
+	return true
+}
+
+// handleUpdateIssue handles PATCH /api/v1/issues/{id}.
+func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
+	if !s.requireWritable(w) || !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+	id := r.PathValue("id")
+	if err := model.ValidateIssueID(id); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAM", err.Error())
+		return
+	}
+
+	var update model.IssueUpdate
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWriteBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_UPDATE", "invalid update: "+err.Error())
+		return
+	}
+	if err := update.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_UPDATE", err.Error())
+		return
+	}
+
+	before, err := s.adapter.GetIssue(ctx, id)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	issue, err := s.adapter.UpdateIssue(ctx, id, update)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	s.NotifyIssueUpdated(issue.ID, issue.Status, before.Status)
+	s.workIndex(ctx).Annotate(issue)
+	writeJSON(w, http.StatusOK, issue)
+}
+
+// handleAddDependency handles POST /api/v1/issues/{id}/dependencies. The
+// body names the issue that {id} depends on.
+func (s *Server) handleAddDependency(w http.ResponseWriter, r *http.Request) {
+	if !s.requireWritable(w) || !s.checkBeadsInitialized(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+
+	var dep model.Dependency
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWriteBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&dep); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_DEPENDENCY", "invalid dependency: "+err.Error())
+		return
+	}
+	dep.Issue = r.PathValue("id")
+	if err := dep.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_DEPENDENCY", err.Error())
+		return
+	}
+
+	// bd reports unknown issues inconsistently, so check both ends first
+	for _, id := range []string{dep.Issue, dep.DependsOn} {
+		if _, err := s.adapter.GetIssue(ctx, id); err != nil {
+			handleAdapterError(w, err)
+			return
+		}
+	}
+
+	if err := s.adapter.AddDependency(ctx, dep); err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	issue, err := s.adapter.GetIssue(ctx, dep.Issue)
+	if err != nil {
+		handleAdapterError(w, err)
+		return
+	}
+
+	s.NotifyIssueUpdated(issue.ID, issue.Status, issue.Status)
+	writeJSON(w, http.StatusCreated, issue)
+}
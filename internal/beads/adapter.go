@@ -3,6 +3,7 @@ package beads
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
 )
@@ -22,17 +23,31 @@ type Adapter interface {
 	// Graph returns the dependency graph.
 	Graph(ctx context.Context) (*model.Graph, error)
 
+	// IssueEvents returns the status changes of an issue from bd's event log.
+	IssueEvents(ctx context.Context, id string) ([]model.TimelineEntry, error)
+
+	// UpdateIssue applies a partial update and returns the updated issue.
+	UpdateIssue(ctx context.Context, id string, update model.IssueUpdate) (*model.Issue, error)
+
+	// AddDependency records that one issue depends on another.
+	AddDependency(ctx context.Context, dep model.Dependency) error
+
 	// IsInitialized checks if beads is initialized in the current directory.
 	IsInitialized(ctx context.Context) (bool, error)
 
 	// Version returns the bd CLI version.
 	Version(ctx context.Context) (string, error)
+
+	// UnmappedStatuses returns the raw bd statuses seen so far that the
+	// status map does not cover.
+	UnmappedStatuses() []UnmappedStatus
 }
 
 // CLIAdapter implements Adapter by shelling out to the bd CLI.
 type CLIAdapter struct {
 	executor Executor
 	workDir  string
+	statuses *StatusMapper
 }
 
 // NewCLIAdapter creates a new CLI-based adapter.
@@ -41,6 +56,7 @@ func NewCLIAdapter(workDir string) *CLIAdapter {
 	return &CLIAdapter{
 		executor: &DefaultExecutor{},
 		workDir:  workDir,
+		statuses: NewStatusMapper(DefaultStatusMap()),
 	}
 }
 
@@ -49,9 +65,21 @@ func NewCLIAdapterWithExecutor(workDir string, executor Executor) *CLIAdapter {
 	return &CLIAdapter{
 		executor: executor,
 		workDir:  workDir,
+		statuses: NewStatusMapper(DefaultStatusMap()),
 	}
 }
 
+// SetStatusMap replaces the mapping from raw bd statuses to normalized
+// statuses. It must be called before the adapter is used.
+func (a *CLIAdapter) SetStatusMap(statuses StatusMap) {
+	a.statuses = NewStatusMapper(statuses)
+}
+
+// UnmappedStatuses implements Adapter.UnmappedStatuses.
+func (a *CLIAdapter) UnmappedStatuses() []UnmappedStatus {
+	return a.statuses.Unmapped()
+}
+
 // ListIssues implements Adapter.ListIssues.
 func (a *CLIAdapter) ListIssues(ctx context.Context, filter model.IssueFilter) ([]model.Issue, error) {
 	args := []string{"list", "--json"}
@@ -72,7 +100,11 @@ func (a *CLIAdapter) ListIssues(ctx context.Context, filter model.IssueFilter) (
 
 	issues := make([]model.Issue, 0, len(bdIssues))
 	for _, bi := range bdIssues {
-		issues = append(issues, bi.ToModelIssue())
+		issue := bi.toModelIssue(a.statuses)
+		if !filter.Match(issue) {
+			continue
+		}
+		issues = append(issues, issue)
 	}
 
 	return issues, nil
@@ -80,7 +112,7 @@ func (a *CLIAdapter) ListIssues(ctx context.Context, filter model.IssueFilter) (
 
 // GetIssue implements Adapter.GetIssue.
 func (a *CLIAdapter) GetIssue(ctx context.Context, id string) (*model.Issue, error) {
-	output, err := a.executor.Execute(ctx, a.workDir, "show", id, "--json")
+	output, err := a.executor.Execute(ctx, a.workDir, "show", "--json", "--", id)
 	if err != nil {
 		if IsNotFoundError(err) {
 			return nil, &NotFoundError{ID: id}
@@ -97,7 +129,7 @@ func (a *CLIAdapter) GetIssue(ctx context.Context, id string) (*model.Issue, err
 		return nil, &NotFoundError{ID: id}
 	}
 
-	issue := bdIssues[0].ToModelIssue()
+	issue := bdIssues[0].toModelIssue(a.statuses)
 	return &issue, nil
 }
 
@@ -108,16 +140,7 @@ func (a *CLIAdapter) Board(ctx context.Context) (*model.Board, error) {
 		return nil, err
 	}
 
-	board := model.NewBoard()
-	for _, issue := range issues {
-		board.AddIssue(model.IssueSummary{
-			ID:       issue.ID,
-			Title:    issue.Title,
-			Status:   issue.Status,
-			Priority: issue.Priority,
-		})
-	}
-
+	board := model.BuildBoard(model.DefaultBoardConfig(), issues, model.GroupByNone)
 	return &board, nil
 }
 
@@ -141,10 +164,15 @@ func (a *CLIAdapter) Graph(ctx context.Context) (*model.Graph, error) {
 	// Add all issues as nodes
 	for _, bi := range bdIssues {
 		graph.AddNode(model.GraphNode{
-			ID:       bi.ID,
-			Title:    bi.Title,
-			Status:   mapStatus(bi.Status),
-			Priority: mapPriority(bi.Priority),
+			ID:        bi.ID,
+			Title:     bi.Title,
+			Status:    a.statuses.Map(bi.Status, bi.ID),
+			RawStatus: bi.Status,
+			Priority:  mapPriority(bi.Priority),
+			IssueType: bi.IssueType,
+			Assignee:  bi.Assignee,
+			Labels:    bi.Labels,
+			ClosedAt:  bi.ClosedAt,
 		})
 		nodeMap[bi.ID] = true
 	}
@@ -217,6 +245,79 @@ func (a *CLIAdapter) Graph(ctx context.Context) (*model.Graph, error) {
 	return &graph, nil
 }
 
+// IssueEvents implements Adapter.IssueEvents.
+// It fails with an ExecutionError on bd versions without an event log.
+func (a *CLIAdapter) IssueEvents(ctx context.Context, id string) ([]model.TimelineEntry, error) {
+	output, err := a.executor.Execute(ctx, a.workDir, "events", "--json", "--", id)
+	if err != nil {
+		return nil, err
+	}
+
+	bdEvents, err := ParseEventList(output)
+	if err != nil {
+		return nil, &ParseError{Command: "events", Err: err}
+	}
+
+	entries := make([]model.TimelineEntry, 0, len(bdEvents))
+	for _, be := range bdEvents {
+		if entry, ok := be.toTimelineEntry(a.statuses); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// UpdateIssue implements Adapter.UpdateIssue with bd update.
+func (a *CLIAdapter) UpdateIssue(ctx context.Context, id string, update model.IssueUpdate) (*model.Issue, error) {
+	// Flags come first and "--" ends them, so an ID is never read as a flag
+	args := []string{"update"}
+	if update.Title != nil {
+		args = append(args, "--title", *update.Title)
+	}
+	if update.Description != nil {
+		args = append(args, "--description", *update.Description)
+	}
+	if update.Status != nil {
+		args = append(args, "--status", RawStatus(*update.Status))
+	}
+	if update.Priority != nil {
+		args = append(args, "--priority", strconv.Itoa(update.Priority.Level()))
+	}
+	args = append(args, "--", id)
+
+	if _, err := a.executor.Execute(ctx, a.workDir, args...); err != nil {
+		if IsNotFoundError(err) {
+			return nil, &NotFoundError{ID: id}
+		}
+		return nil, err
+	}
+	return a.GetIssue(ctx, id)
+}
+
+// AddDependency implements Adapter.AddDependency with bd dep add.
+func (a *CLIAdapter) AddDependency(ctx context.Context, dep model.Dependency) error {
+	_, err := a.executor.Execute(ctx, a.workDir,
+		"dep", "add", "--type", mapEdgeTypeToDepType(dep.Type), "--", dep.Issue, dep.DependsOn)
+	return err
+}
+
+// mapEdgeTypeToDepType converts a model.EdgeType to a bd dependency type.
+func mapEdgeTypeToDepType(edgeType model.EdgeType) string {
+	switch edgeType {
+	case "", model.EdgeTypeBlocks:
+		return "blocks"
+	case model.EdgeTypeParent, model.EdgeTypeChild:
+		return "parent-child"
+	case model.EdgeTypeRelates:
+		return "related"
+	case model.EdgeTypeDerivedFrom:
+		return "discovered-from"
+	default:
+		return string(edgeType)
+	}
+}
+
 // mapDepTypeToEdgeType converts bd dependency_type to model.EdgeType.
 func mapDepTypeToEdgeType(depType string) model.EdgeType {
 	switch depType {
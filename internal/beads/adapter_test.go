@@ -36,7 +36,7 @@ func TestCLIAdapterListIssues(t *testing.T) {
 
 func TestCLIAdapterGetIssue(t *testing.T) {
 	mock := NewMockExecutor()
-	mock.SetResponse("show test-1 --json", []byte(`[
+	mock.SetResponse("show --json -- test-1", []byte(`[
 		{
 			"id": "test-1",
 			"title": "Test Issue",
@@ -64,7 +64,7 @@ func TestCLIAdapterGetIssue(t *testing.T) {
 
 func TestCLIAdapterGetIssueNotFound(t *testing.T) {
 	mock := NewMockExecutor()
-	mock.SetError("show nonexistent --json", &NotFoundError{ID: "nonexistent"})
+	mock.SetError("show --json -- nonexistent", &NotFoundError{ID: "nonexistent"})
 
 	adapter := NewCLIAdapterWithExecutor("", mock)
 	ctx := context.Background()
@@ -190,3 +190,148 @@ func TestCLIAdapterBDNotFound(t *testing.T) {
 		t.Errorf("expected BDNotFoundError, got %T: %v", err, err)
 	}
 }
+
+func TestCLIAdapterIssueEvents(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetResponse("events --json -- test-1", []byte(`[
+		{"issue_id": "test-1", "event_type": "created", "actor": "mayor", "created_at": "2026-01-01T10:00:00Z"},
+		{"issue_id": "test-1", "event_type": "commented", "actor": "nux", "created_at": "2026-01-01T10:30:00Z"},
+		{"issue_id": "test-1", "event_type": "status_changed", "actor": "nux", "old_value": "open", "new_value": "in_progress", "created_at": "2026-01-01T11:00:00Z"},
+		{"issue_id": "test-1", "event_type": "closed", "actor": "nux", "created_at": "2026-01-01T12:00:00Z"}
+	]`))
+
+	adapter := NewCLIAdapterWithExecutor("", mock)
+	ctx := context.Background()
+
+	entries, err := adapter.IssueEvents(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 status entries, got %d", len(entries))
+	}
+	if entries[1].From != model.StatusPending || entries[1].To != model.StatusInProgress {
+		t.Errorf("expected pending -> in_progress, got %s -> %s", entries[1].From, entries[1].To)
+	}
+	if entries[1].Actor != "nux" {
+		t.Errorf("expected actor 'nux', got '%s'", entries[1].Actor)
+	}
+	if entries[2].To != model.StatusDone {
+		t.Errorf("expected closed event to map to done, got %s", entries[2].To)
+	}
+}
+
+func TestCLIAdapterStatusMap(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetResponse("list --json", []byte(`[
+		{"id": "test-1", "title": "Parked", "status": "deferred", "priority": 2},
+		{"id": "test-2", "title": "Gone", "status": "tombstone", "priority": 2},
+		{"id": "test-3", "title": "Shipped", "status": "closed", "priority": 2}
+	]`))
+
+	statuses, err := ParseStatusMap([]byte(`{"deferred": "blocked"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	adapter := NewCLIAdapterWithExecutor("", mock)
+	adapter.SetStatusMap(statuses)
+
+	issues, err := adapter.ListIssues(context.Background(), model.NewIssueFilter())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if issues[0].Status != model.StatusBlocked || issues[0].RawStatus != "deferred" {
+		t.Errorf("expected deferred to map to blocked, got %s (raw %s)", issues[0].Status, issues[0].RawStatus)
+	}
+	if issues[1].Status != model.StatusUnknown {
+		t.Errorf("expected tombstone to be unknown, got %s", issues[1].Status)
+	}
+	if issues[2].Status != model.StatusDone || issues[2].RawStatus != "closed" {
+		t.Errorf("expected closed to keep its raw status, got %s (raw %s)", issues[2].Status, issues[2].RawStatus)
+	}
+
+	unmapped := adapter.UnmappedStatuses()
+	if len(unmapped) != 1 || unmapped[0].Status != "tombstone" || unmapped[0].Example != "test-2" {
+		t.Errorf("expected tombstone to be reported as unmapped, got %+v", unmapped)
+	}
+}
+
+func TestParseStatusMapRejectsUnknownTarget(t *testing.T) {
+	if _, err := ParseStatusMap([]byte(`{"review": "reviewing"}`)); err == nil {
+		t.Error("expected an error for a mapping to an unknown status")
+	}
+}
+
+func TestCLIAdapterListIssuesUnmetCriteria(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetResponse("list --json", []byte(`[
+		{"id": "test-1", "status": "closed", "description": "Done when:\n- [x] a\n- [ ] b"},
+		{"id": "test-2", "status": "closed", "description": "Done when:\n- [x] a"},
+		{"id": "test-3", "status": "open", "description": "Done when:\n- [ ] a"},
+		{"id": "test-4", "status": "closed", "description": "Done when:\n- a"}
+	]`))
+
+	adapter := NewCLIAdapterWithExecutor("", mock)
+	filter := model.NewIssueFilter()
+	filter.UnmetCriteria = true
+
+	issues, err := adapter.ListIssues(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].ID != "test-1" {
+		t.Errorf("expected only the closed issue with an unchecked box, got %+v", issues)
+	}
+}
+
+func TestCLIAdapterUpdateIssue(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetResponse("update --title Renamed --status closed --priority 0 -- test-1", []byte("Updated test-1\n"))
+	mock.SetResponse("show --json -- test-1", []byte(`[
+		{"id": "test-1", "title": "Renamed", "status": "closed", "priority": 0}
+	]`))
+
+	adapter := NewCLIAdapterWithExecutor("", mock)
+	ctx := context.Background()
+
+	title, status, priority := "Renamed", "done", model.PriorityCritical
+	issue, err := adapter.UpdateIssue(ctx, "test-1", model.IssueUpdate{
+		Title:    &title,
+		Status:   &status,
+		Priority: &priority,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issue.Title != "Renamed" || issue.Status != model.StatusDone || issue.Priority != model.PriorityCritical {
+		t.Errorf("unexpected issue after update: %+v", issue)
+	}
+
+	// Raw statuses are passed through unchanged
+	raw := "deferred"
+	mock.SetError("update --status deferred -- test-1", &NotFoundError{})
+	if _, err := adapter.UpdateIssue(ctx, "test-1", model.IssueUpdate{Status: &raw}); !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestCLIAdapterAddDependency(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetResponse("dep add --type blocks -- test-2 test-1", []byte(""))
+	mock.SetResponse("dep add --type parent-child -- test-2 test-3", []byte(""))
+
+	adapter := NewCLIAdapterWithExecutor("", mock)
+	ctx := context.Background()
+
+	if err := adapter.AddDependency(ctx, model.Dependency{Issue: "test-2", DependsOn: "test-1"}); err != nil {
+		t.Errorf("blocks dependency: %v", err)
+	}
+	dep := model.Dependency{Issue: "test-2", DependsOn: "test-3", Type: model.EdgeTypeParent}
+	if err := adapter.AddDependency(ctx, dep); err != nil {
+		t.Errorf("parent dependency: %v", err)
+	}
+}
@@ -62,7 +62,12 @@ func (e *DefaultExecutor) Execute(ctx context.Context, workDir string, args ...s
 // extractIDFromArgs attempts to extract an issue ID from command args.
 func extractIDFromArgs(args []string) string {
 	for i, arg := range args {
-		if arg == "show" && i+1 < len(args) {
+		if arg == "--" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	for i, arg := range args {
+		if (arg == "show" || arg == "update") && i+1 < len(args) {
 			return args[i+1]
 		}
 	}
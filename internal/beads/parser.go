@@ -16,6 +16,8 @@ type BDIssue struct {
 	Status          string        `json:"status"`
 	Priority        int           `json:"priority"`
 	IssueType       string        `json:"issue_type"`
+	Assignee        string        `json:"assignee,omitempty"`
+	Labels          []string      `json:"labels,omitempty"`
 	CreatedAt       time.Time     `json:"created_at"`
 	UpdatedAt       time.Time     `json:"updated_at"`
 	ClosedAt        *time.Time    `json:"closed_at,omitempty"`
@@ -28,135 +30,185 @@ type BDIssue struct {
 	DepType         string        `json:"dependency_type,omitempty"`
 }
 
-// ToModelIssue converts a BDIssue to the domain model Issue.
-func (bi *BDIssue) ToModelIssue() model.Issue {
+// toModelIssue converts a BDIssue to the domain model Issue, mapping
+// statuses with statuses (the default mapping when nil).
+func (bi *BDIssue) toModelIssue(statuses *StatusMapper) model.Issue {
 	issue := model.Issue{
 		ID:          bi.ID,
 		Title:       bi.Title,
 		Description: bi.Description,
-		Status:      mapStatus(bi.Status),
+		Status:      statuses.Map(bi.Status, bi.ID),
+		RawStatus:   bi.Status,
 		Priority:    mapPriority(bi.Priority),
+		IssueType:   bi.IssueType,
+		Assignee:    bi.Assignee,
+		Labels:      bi.Labels,
 		CreatedAt:   bi.CreatedAt,
 		UpdatedAt:   bi.UpdatedAt,
+		ClosedAt:    bi.ClosedAt,
 		Children:    []model.IssueSummary{},
 		Blocks:      []model.IssueSummary{},
 		BlockedBy:   []model.IssueSummary{},
 	}
 
-	// Parse "Done when:" from description
-	issue.DoneWhen = parseDoneWhen(bi.Description)
+	// Parse acceptance criteria from description
+	issue.Criteria = parseCriteria(bi.Description)
+	for _, c := range issue.Criteria {
+		issue.DoneWhen = append(issue.DoneWhen, c.Text)
+	}
+	issue.CriteriaProgress = model.NewCriteriaProgress(issue.Criteria)
 
 	// Map dependencies to BlockedBy (things this issue depends on)
 	for _, dep := range bi.Dependencies {
 		if dep.DepType == "blocks" {
-			issue.BlockedBy = append(issue.BlockedBy, model.IssueSummary{
-				ID:       dep.ID,
-				Title:    dep.Title,
-				Status:   mapStatus(dep.Status),
-				Priority: mapPriority(dep.Priority),
-			})
+			issue.BlockedBy = append(issue.BlockedBy, dep.toSummary(statuses))
 		} else if dep.DepType == "parent-child" {
-			issue.Parent = &model.IssueSummary{
-				ID:       dep.ID,
-				Title:    dep.Title,
-				Status:   mapStatus(dep.Status),
-				Priority: mapPriority(dep.Priority),
-			}
+			parent := dep.toSummary(statuses)
+			issue.Parent = &parent
 		}
 	}
 
 	// Map dependents to Blocks (things that depend on this issue)
 	for _, dep := range bi.Dependents {
 		if dep.DepType == "blocks" {
-			issue.Blocks = append(issue.Blocks, model.IssueSummary{
-				ID:       dep.ID,
-				Title:    dep.Title,
-				Status:   mapStatus(dep.Status),
-				Priority: mapPriority(dep.Priority),
-			})
+			issue.Blocks = append(issue.Blocks, dep.toSummary(statuses))
 		} else if dep.DepType == "parent-child" {
-			issue.Children = append(issue.Children, model.IssueSummary{
-				ID:       dep.ID,
-				Title:    dep.Title,
-				Status:   mapStatus(dep.Status),
-				Priority: mapPriority(dep.Priority),
-			})
+			issue.Children = append(issue.Children, dep.toSummary(statuses))
 		}
 	}
 
 	return issue
 }
 
-// ToSummary converts a BDIssue to an IssueSummary.
-func (bi *BDIssue) ToSummary() model.IssueSummary {
+// toSummary converts a BDIssue to an IssueSummary.
+func (bi *BDIssue) toSummary(statuses *StatusMapper) model.IssueSummary {
 	return model.IssueSummary{
-		ID:       bi.ID,
-		Title:    bi.Title,
-		Status:   mapStatus(bi.Status),
-		Priority: mapPriority(bi.Priority),
-	}
-}
-
-// mapStatus converts bd status string to model.Status.
-func mapStatus(s string) model.Status {
-	switch strings.ToLower(s) {
-	case "open", "pending":
-		return model.StatusPending
-	case "in_progress", "in-progress", "inprogress":
-		return model.StatusInProgress
-	case "closed", "done", "complete":
-		return model.StatusDone
-	case "blocked":
-		return model.StatusBlocked
-	default:
-		return model.StatusPending
+		ID:        bi.ID,
+		Title:     bi.Title,
+		Status:    statuses.Map(bi.Status, bi.ID),
+		RawStatus: bi.Status,
+		Priority:  mapPriority(bi.Priority),
+		IssueType: bi.IssueType,
+		Assignee:  bi.Assignee,
+		Labels:    bi.Labels,
+		ClosedAt:  bi.ClosedAt,
 	}
 }
 
-// mapPriority converts bd priority int to model.Priority.
+// mapPriority converts bd's P0-P4 priority to model.Priority. Values
+// outside bd's scale fall back to medium, bd's default.
 func mapPriority(p int) model.Priority {
 	switch p {
+	case 0:
+		return model.PriorityCritical
 	case 1:
 		return model.PriorityHigh
 	case 2:
 		return model.PriorityMedium
 	case 3:
 		return model.PriorityLow
+	case 4:
+		return model.PriorityBacklog
 	default:
 		return model.PriorityMedium
 	}
 }
 
-// parseDoneWhen extracts "Done when:" bullets from description.
-func parseDoneWhen(description string) []string {
-	var items []string
+// criteriaHeaders are the section headings that introduce acceptance
+// criteria, matched case-insensitively after Markdown heading and emphasis
+// markers are stripped.
+var criteriaHeaders = []string{"done when", "acceptance criteria", "definition of done"}
 
-	lines := strings.Split(description, "\n")
-	inDoneWhen := false
+// parseCriteria extracts acceptance criteria from every criteria section in
+// description. Items may be bulleted (-, *, +) or numbered (1. or 1)), and
+// may carry a Markdown checkbox. A section ends at an empty line after its
+// first item, at a line that is not a list item, or at the next heading.
+func parseCriteria(description string) []model.Criterion {
+	var items []model.Criterion
+	section := ""
+	started := false
 
-	for _, line := range lines {
+	for _, line := range strings.Split(description, "\n") {
 		line = strings.TrimSpace(line)
 
-		if strings.HasPrefix(strings.ToLower(line), "done when:") {
-			inDoneWhen = true
+		if name, ok := criteriaHeader(line); ok {
+			section = name
+			started = false
+			continue
+		}
+		if section == "" {
 			continue
 		}
 
-		if inDoneWhen {
-			// Empty line ends the section
-			if line == "" {
-				break
-			}
-			// Lines starting with - are items
-			if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
-				items = append(items, strings.TrimPrefix(strings.TrimPrefix(line, "- "), "* "))
+		if line == "" {
+			if started {
+				section = ""
 			}
+			continue
+		}
+
+		text, ok := listItem(line)
+		if !ok {
+			section = ""
+			continue
+		}
+		started = true
+
+		item := model.Criterion{Text: text, Section: section}
+		if rest, ok := cutCheckbox(text); ok {
+			item.Text = rest
+			item.Checkbox = true
+			item.Checked = strings.HasPrefix(text, "[x]") || strings.HasPrefix(text, "[X]")
 		}
+		items = append(items, item)
 	}
 
 	return items
 }
 
+// criteriaHeader reports whether line opens a criteria section and returns
+// the section name without Markdown markers or the trailing colon. As
+// before, any text following "Done when:" on the same line is ignored.
+func criteriaHeader(line string) (string, bool) {
+	name := strings.TrimLeft(line, "#*_ ")
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimRight(name, "*_ ")
+	for _, h := range criteriaHeaders {
+		if strings.EqualFold(name, h) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// listItem returns the text of a bulleted or numbered list item.
+func listItem(line string) (string, bool) {
+	for _, bullet := range []string{"- ", "* ", "+ "} {
+		if rest, ok := strings.CutPrefix(line, bullet); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits+1 < len(line) && (line[digits] == '.' || line[digits] == ')') && line[digits+1] == ' ' {
+		return strings.TrimSpace(line[digits+2:]), true
+	}
+	return "", false
+}
+
+// cutCheckbox strips a leading "[ ]", "[x]" or "[X]" from text.
+func cutCheckbox(text string) (string, bool) {
+	if len(text) >= 3 && text[0] == '[' && text[2] == ']' && strings.ContainsRune(" xX", rune(text[1])) {
+		return strings.TrimSpace(text[3:]), true
+	}
+	return text, false
+}
+
 // ParseIssueList parses JSON output from bd list or bd show.
 func ParseIssueList(data []byte) ([]BDIssue, error) {
 	if len(data) == 0 {
@@ -193,6 +245,59 @@ func ParseBlockedList(data []byte) ([]BDBlockedIssue, error) {
 	return issues, nil
 }
 
+// BDEvent represents an entry from bd's event log (bd events --json).
+type BDEvent struct {
+	IssueID   string    `json:"issue_id"`
+	EventType string    `json:"event_type"`
+	Actor     string    `json:"actor"`
+	OldValue  string    `json:"old_value,omitempty"`
+	NewValue  string    `json:"new_value,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// toTimelineEntry converts a status-related event to a timeline entry.
+// The second return value is false for events that don't change status.
+func (be *BDEvent) toTimelineEntry(statuses *StatusMapper) (model.TimelineEntry, bool) {
+	entry := model.TimelineEntry{
+		Time:   be.CreatedAt,
+		Actor:  be.Actor,
+		Source: model.TimelineSourceBD,
+	}
+
+	switch be.EventType {
+	case "created":
+		entry.To = model.StatusPending
+	case "status_changed":
+		if be.OldValue != "" {
+			entry.From = statuses.Map(be.OldValue, be.IssueID)
+		}
+		entry.To = statuses.Map(be.NewValue, be.IssueID)
+	case "closed":
+		entry.To = model.StatusDone
+	case "reopened":
+		entry.To = model.StatusPending
+	default:
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// ParseEventList parses JSON output from bd events.
+func ParseEventList(data []byte) ([]BDEvent, error) {
+	if len(data) == 0 {
+		return []BDEvent{}, nil
+	}
+
+	var events []BDEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 // ParseVersion extracts version number from bd --version output.
 func ParseVersion(data []byte) string {
 	s := strings.TrimSpace(string(data))
@@ -41,6 +41,45 @@ func TestParseIssueList(t *testing.T) {
 	}
 }
 
+func TestToModelIssueCarriesAllFields(t *testing.T) {
+	input := []byte(`[
+		{
+			"id": "test-2",
+			"title": "Outage",
+			"status": "closed",
+			"priority": 0,
+			"issue_type": "bug",
+			"assignee": "gastown/polecats/nux",
+			"labels": ["incident", "api"],
+			"created_at": "2026-01-01T10:00:00Z",
+			"updated_at": "2026-01-01T12:00:00Z",
+			"closed_at": "2026-01-01T11:30:00Z",
+			"dependents": [
+				{"id": "test-3", "title": "Follow-up", "status": "open", "priority": 4, "issue_type": "task", "dependency_type": "blocks"}
+			]
+		}
+	]`)
+
+	issues, err := ParseIssueList(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	issue := issues[0].toModelIssue(nil)
+	if issue.Priority != model.PriorityCritical {
+		t.Errorf("expected critical priority, got %q", issue.Priority)
+	}
+	if issue.IssueType != "bug" || issue.Assignee != "gastown/polecats/nux" || len(issue.Labels) != 2 {
+		t.Errorf("expected type, assignee and labels to be kept, got %+v", issue)
+	}
+	if issue.ClosedAt == nil || issue.ClosedAt.Hour() != 11 {
+		t.Errorf("expected closed_at to be kept, got %v", issue.ClosedAt)
+	}
+	if len(issue.Blocks) != 1 || issue.Blocks[0].Priority != model.PriorityBacklog || issue.Blocks[0].IssueType != "task" {
+		t.Errorf("expected dependent summary with backlog priority and type, got %+v", issue.Blocks)
+	}
+}
+
 func TestMapStatus(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -53,14 +92,17 @@ func TestMapStatus(t *testing.T) {
 		{"closed", model.StatusDone},
 		{"done", model.StatusDone},
 		{"blocked", model.StatusBlocked},
-		{"unknown", model.StatusPending},
+		{"CLOSED", model.StatusDone},
+		{"deferred", model.StatusUnknown},
+		{"tombstone", model.StatusUnknown},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			result := mapStatus(tt.input)
+			var statuses *StatusMapper // the default mapping
+			result := statuses.Map(tt.input, "")
 			if result != tt.expected {
-				t.Errorf("mapStatus(%q) = %q, want %q", tt.input, result, tt.expected)
+				t.Errorf("Map(%q) = %q, want %q", tt.input, result, tt.expected)
 			}
 		})
 	}
@@ -71,10 +113,11 @@ func TestMapPriority(t *testing.T) {
 		input    int
 		expected model.Priority
 	}{
+		{0, model.PriorityCritical},
 		{1, model.PriorityHigh},
 		{2, model.PriorityMedium},
 		{3, model.PriorityLow},
-		{0, model.PriorityMedium},
+		{4, model.PriorityBacklog},
 		{99, model.PriorityMedium},
 	}
 
@@ -96,7 +139,8 @@ Done when:
 
 Additional notes here.`
 
-	items := parseDoneWhen(description)
+	bdIssue := BDIssue{ID: "test-1", Description: description}
+	items := bdIssue.toModelIssue(nil).DoneWhen
 
 	if len(items) != 3 {
 		t.Fatalf("expected 3 items, got %d", len(items))
@@ -110,6 +154,45 @@ Additional notes here.`
 	}
 }
 
+func TestParseCriteria(t *testing.T) {
+	description := `Ship the exporter.
+
+## Done when
+- [x] CSV export works
+- [ ] JSONL export works
+  - [X] nested item counts too
+
+Acceptance criteria:
+
+1. Documented in the README
+2) [ ] Covered by tests
+
+Notes after the list.`
+
+	items := parseCriteria(description)
+
+	if len(items) != 5 {
+		t.Fatalf("expected 5 criteria, got %d: %+v", len(items), items)
+	}
+	if !items[0].Checked || items[1].Checked || !items[2].Checked {
+		t.Errorf("unexpected checkbox states: %+v", items[:3])
+	}
+	if items[0].Section != "Done when" || items[3].Section != "Acceptance criteria" {
+		t.Errorf("unexpected sections %q and %q", items[0].Section, items[3].Section)
+	}
+	if items[3].Checkbox || items[3].Text != "Documented in the README" {
+		t.Errorf("expected a plain numbered item, got %+v", items[3])
+	}
+	if items[4].Text != "Covered by tests" || !items[4].Checkbox {
+		t.Errorf("expected a numbered checkbox item, got %+v", items[4])
+	}
+
+	progress := model.NewCriteriaProgress(items)
+	if progress == nil || progress.Total != 4 || progress.Checked != 2 || progress.Percent != 50 {
+		t.Errorf("expected 2 of 4 checkboxes checked, got %+v", progress)
+	}
+}
+
 func TestParseVersion(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -143,7 +226,7 @@ func TestBDIssueToModelIssue(t *testing.T) {
 		},
 	}
 
-	issue := bdIssue.ToModelIssue()
+	issue := bdIssue.toModelIssue(nil)
 
 	if issue.ID != "test-1" {
 		t.Errorf("expected ID 'test-1', got '%s'", issue.ID)
@@ -0,0 +1,144 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// StatusMap maps raw bd statuses (lowercase) to normalized statuses.
+type StatusMap map[string]model.Status
+
+// DefaultStatusMap returns the mapping for the statuses bd is known to emit.
+func DefaultStatusMap() StatusMap {
+	return StatusMap{
+		"open":        model.StatusPending,
+		"pending":     model.StatusPending,
+		"in_progress": model.StatusInProgress,
+		"in-progress": model.StatusInProgress,
+		"inprogress":  model.StatusInProgress,
+		"closed":      model.StatusDone,
+		"done":        model.StatusDone,
+		"complete":    model.StatusDone,
+		"blocked":     model.StatusBlocked,
+	}
+}
+
+// ParseStatusMap parses a JSON object of raw bd status to normalized status
+// and merges it over the default mapping, so a file only needs to list the
+// statuses it adds or changes.
+func ParseStatusMap(data []byte) (StatusMap, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse status map: %w", err)
+	}
+
+	m := DefaultStatusMap()
+	for from, to := range raw {
+		status := model.Status(strings.ToLower(to))
+		if !status.Valid() {
+			return nil, fmt.Errorf("status map: %q maps to %q, want pending, in_progress, done or blocked", from, to)
+		}
+		m[strings.ToLower(from)] = status
+	}
+	return m, nil
+}
+
+// lookup returns the normalized status for raw, or StatusUnknown.
+func (m StatusMap) lookup(raw string) (model.Status, bool) {
+	status, ok := m[strings.ToLower(raw)]
+	if !ok {
+		return model.StatusUnknown, false
+	}
+	return status, true
+}
+
+var defaultStatusMap = DefaultStatusMap()
+
+// UnmappedStatus is a raw bd status the status map has no entry for.
+type UnmappedStatus struct {
+	Status    string    `json:"status"`
+	Example   string    `json:"example"` // ID of an issue last seen with the status
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// StatusMapper applies a StatusMap and remembers the raw statuses it could
+// not map, so format drift in newer bd releases can be reported.
+type StatusMapper struct {
+	statuses StatusMap
+
+	mu       sync.Mutex
+	unmapped map[string]*UnmappedStatus
+}
+
+// NewStatusMapper creates a mapper for the given status map.
+func NewStatusMapper(statuses StatusMap) *StatusMapper {
+	return &StatusMapper{
+		statuses: statuses,
+		unmapped: make(map[string]*UnmappedStatus),
+	}
+}
+
+// Map returns the normalized status of an issue's raw status. A nil mapper
+// uses the default mapping and records nothing.
+func (m *StatusMapper) Map(raw, issueID string) model.Status {
+	if m == nil {
+		status, _ := defaultStatusMap.lookup(raw)
+		return status
+	}
+
+	status, ok := m.statuses.lookup(raw)
+	if ok {
+		return status
+	}
+
+	now := time.Now()
+	key := strings.ToLower(raw)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, seen := m.unmapped[key]
+	if !seen {
+		u = &UnmappedStatus{Status: key, FirstSeen: now}
+		m.unmapped[key] = u
+	}
+	u.Example = issueID
+	u.LastSeen = now
+	return status
+}
+
+// Unmapped returns the raw statuses seen without a mapping, sorted by name.
+func (m *StatusMapper) Unmapped() []UnmappedStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]UnmappedStatus, 0, len(m.unmapped))
+	for _, u := range m.unmapped {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Status < result[j].Status })
+	return result
+}
+
+// RawStatus returns the bd status to write for status. Normalized statuses
+// are translated to bd's built-in names; anything else is assumed to be a
+// raw bd status already.
+func RawStatus(status string) string {
+	switch model.Status(status) {
+	case model.StatusPending:
+		return "open"
+	case model.StatusInProgress:
+		return "in_progress"
+	case model.StatusDone:
+		return "closed"
+	case model.StatusBlocked:
+		return "blocked"
+	}
+	return status
+}
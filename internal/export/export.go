@@ -0,0 +1,152 @@
+// Package export writes issues as CSV or JSON Lines and renders Markdown
+// status reports.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Format is an export format.
+type Format string
+
+const (
+	FormatCSV      Format = "csv"
+	FormatJSONL    Format = "jsonl"
+	FormatMarkdown Format = "markdown"
+)
+
+// ParseFormat parses an export format name. "md" is accepted for Markdown
+// and "ndjson" for JSON Lines.
+func ParseFormat(s string) (Format, bool) {
+	switch strings.ToLower(s) {
+	case "csv":
+		return FormatCSV, true
+	case "jsonl", "ndjson":
+		return FormatJSONL, true
+	case "markdown", "md":
+		return FormatMarkdown, true
+	}
+	return "", false
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// Extension returns the file extension of the format.
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return "md"
+	}
+	return string(f)
+}
+
+// csvHeader lists the CSV columns. List-valued columns are joined with ";".
+var csvHeader = []string{
+	"id", "title", "status", "raw_status", "priority", "type", "assignee", "labels", "parent",
+	"blocked_by", "criteria_checked", "criteria_total", "agent", "created_at", "updated_at", "closed_at",
+}
+
+// WriteCSV writes issues as CSV with a header row.
+func WriteCSV(w io.Writer, issues []model.Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		var parent, agent, checked, total string
+		if issue.Parent != nil {
+			parent = issue.Parent.ID
+		}
+		if len(issue.Agents) > 0 {
+			agent = issue.Agents[0].Address
+		}
+		if p := issue.CriteriaProgress; p != nil {
+			checked = strconv.Itoa(p.Checked)
+			total = strconv.Itoa(p.Total)
+		}
+		blockedBy := make([]string, len(issue.BlockedBy))
+		for i, dep := range issue.BlockedBy {
+			blockedBy[i] = dep.ID
+		}
+
+		record := []string{
+			issue.ID,
+			issue.Title,
+			string(issue.Status),
+			issue.RawStatus,
+			string(issue.Priority),
+			issue.IssueType,
+			issue.Assignee,
+			strings.Join(issue.Labels, ";"),
+			parent,
+			strings.Join(blockedBy, ";"),
+			checked,
+			total,
+			agent,
+			formatTime(issue.CreatedAt),
+			formatTime(issue.UpdatedAt),
+			formatTimePtr(issue.ClosedAt),
+		}
+		for i, cell := range record {
+			record[i] = csvCell(cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell neutralizes a cell that a spreadsheet would evaluate as a
+// formula, such as a title of "=HYPERLINK(...)", by prefixing a quote.
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// WriteJSONL writes one JSON object per issue per line.
+func WriteJSONL(w io.Writer, issues []model.Issue) error {
+	enc := json.NewEncoder(w)
+	for _, issue := range issues {
+		if err := enc.Encode(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
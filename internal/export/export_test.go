@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func testIssues() []model.Issue {
+	closed := now.Add(-24 * time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+	return []model.Issue{
+		{
+			ID: "gt-1", Title: "Fix login, again", Status: model.StatusInProgress, Priority: model.PriorityHigh,
+			Labels: []string{"ui", "auth"}, BlockedBy: []model.IssueSummary{{ID: "gt-2", Status: model.StatusPending}},
+			CriteriaProgress: &model.CriteriaProgress{Total: 2, Checked: 1}, CreatedAt: old,
+			Agents: []model.AgentRef{{Address: "gastown/nux"}},
+		},
+		{ID: "gt-2", Title: "Session | store", Status: model.StatusBlocked, Priority: model.PriorityCritical},
+		{ID: "gt-3", Title: "Docs", Status: model.StatusDone, Priority: model.PriorityLow, ClosedAt: &closed},
+		{ID: "gt-4", Title: "Old work", Status: model.StatusDone, Priority: model.PriorityLow, ClosedAt: &old},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected header and 4 rows, got %d", len(records))
+	}
+
+	row := make(map[string]string)
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	if row["title"] != "Fix login, again" || row["labels"] != "ui;auth" || row["blocked_by"] != "gt-2" {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if row["criteria_checked"] != "1" || row["agent"] != "gastown/nux" || row["created_at"] != "2026-02-08T12:00:00Z" {
+		t.Errorf("unexpected row: %v", row)
+	}
+}
+
+func TestWriteCSVFormulas(t *testing.T) {
+	titles := []string{"=HYPERLINK(\"http://evil\")", "@SUM(A1)", "+1", "-2", "\tx", "\rx", "fine = ok"}
+	issues := make([]model.Issue, len(titles))
+	for i, title := range titles {
+		issues[i] = model.Issue{ID: "gt-1", Title: title, Labels: []string{"=cmd", "ui"}}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	for i, title := range titles {
+		want := "'" + title
+		if i == len(titles)-1 {
+			want = title
+		}
+		if got := records[i+1][1]; got != want {
+			t.Errorf("title %q written as %q, want %q", title, got, want)
+		}
+		if got := records[i+1][7]; got != "'=cmd;ui" {
+			t.Errorf("labels written as %q", got)
+		}
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, testIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+	var issue model.Issue
+	if err := json.Unmarshal([]byte(lines[2]), &issue); err != nil || issue.ID != "gt-3" {
+		t.Errorf("expected gt-3 on line 3, got %+v (%v)", issue, err)
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	issues := testIssues()
+	board := model.BuildBoard(model.DefaultBoardConfig(), issues, model.GroupByNone)
+	agents := []gastown.Agent{
+		{Role: gastown.RolePolecat, Name: "nux", Rig: "gastown", Status: gastown.StatusActive, HookBead: "gt-1"},
+		{Role: gastown.RoleCrew, Name: "max", Rig: "gastown", Status: gastown.StatusOffline},
+	}
+	convoys := []gastown.Convoy{{ID: "hq-cv-1", Title: "Auth | SSO", Status: gastown.ConvoyStatusInProgress, Total: 3, Completed: 1}}
+
+	report := NewReport(issues, board, agents, convoys, now.Add(-7*24*time.Hour), now, now)
+	if len(report.Closed) != 1 || report.Closed[0].ID != "gt-3" {
+		t.Errorf("expected only gt-3 closed in the window, got %+v", report.Closed)
+	}
+	if len(report.Blocked) != 2 || report.Blocked[0].Issue.ID != "gt-2" {
+		t.Errorf("expected gt-2 then gt-1 blocked, got %+v", report.Blocked)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"# Status report: 2026-03-03 to 2026-03-10",
+		"| **Total** | **4** |",
+		"## Closed (1)\n\n- **gt-3** Docs _(low, closed Mar 9)_",
+		"- **gt-1** Fix login, again _(high)_: waiting on gt-2",
+		"- **gt-2** Session | store _(critical)_",
+		`| Auth \| SSO (hq-cv-1) | in_progress | 1 / 3 | 0 |`,
+		"2 agents: 1 active, 1 offline.",
+		"| gastown/nux | active | gt-1 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected report to contain %q\n\ngot:\n%s", want, got)
+		}
+	}
+}
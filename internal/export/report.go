@@ -0,0 +1,187 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Report is a status report for a time window.
+type Report struct {
+	Since     time.Time
+	Until     time.Time
+	Generated time.Time
+
+	Board   model.Board
+	Closed  []model.Issue // closed within the window, most recent first
+	Blocked []Blocker
+	Convoys []gastown.Convoy
+	Agents  []gastown.Agent
+}
+
+// Blocker is a blocked issue and the open issues it waits on.
+type Blocker struct {
+	Issue     model.Issue
+	WaitingOn []model.IssueSummary
+}
+
+// NewReport builds a report over issues for the window [since, until).
+// Issues without a closed_at timestamp count as closed at their last update.
+func NewReport(issues []model.Issue, board model.Board, agents []gastown.Agent, convoys []gastown.Convoy,
+	since, until, now time.Time) Report {
+	r := Report{
+		Since:     since,
+		Until:     until,
+		Generated: now,
+		Board:     board,
+		Convoys:   convoys,
+		Agents:    agents,
+	}
+
+	for _, issue := range issues {
+		if issue.Status == model.StatusDone {
+			closed := closedAt(issue)
+			if !closed.Before(since) && closed.Before(until) {
+				r.Closed = append(r.Closed, issue)
+			}
+			continue
+		}
+
+		var waiting []model.IssueSummary
+		for _, dep := range issue.BlockedBy {
+			if dep.Status != model.StatusDone {
+				waiting = append(waiting, dep)
+			}
+		}
+		if issue.Status == model.StatusBlocked || len(waiting) > 0 {
+			r.Blocked = append(r.Blocked, Blocker{Issue: issue, WaitingOn: waiting})
+		}
+	}
+
+	sort.SliceStable(r.Closed, func(i, j int) bool {
+		return closedAt(r.Closed[i]).After(closedAt(r.Closed[j]))
+	})
+	sort.SliceStable(r.Blocked, func(i, j int) bool {
+		return r.Blocked[i].Issue.Priority.Level() < r.Blocked[j].Issue.Priority.Level()
+	})
+
+	return r
+}
+
+func closedAt(issue model.Issue) time.Time {
+	if issue.ClosedAt != nil {
+		return *issue.ClosedAt
+	}
+	return issue.UpdatedAt
+}
+
+// WriteMarkdown renders the report as Markdown suitable for pasting into
+// a document.
+func (r Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Status report: %s to %s\n\n", r.Since.Format("2006-01-02"), r.Until.Format("2006-01-02"))
+	fmt.Fprintf(&b, "_Generated %s_\n\n", r.Generated.Format("2006-01-02 15:04 MST"))
+
+	b.WriteString("## Board\n\n")
+	b.WriteString("| Column | Issues |\n|--------|-------:|\n")
+	for _, col := range r.Board.Columns {
+		count := fmt.Sprintf("%d", col.Count)
+		if col.WIPLimit > 0 {
+			count = fmt.Sprintf("%d / %d", col.Count, col.WIPLimit)
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", cell(col.Label), count)
+	}
+	fmt.Fprintf(&b, "| **Total** | **%d** |\n\n", r.Board.Total)
+
+	fmt.Fprintf(&b, "## Closed (%d)\n\n", len(r.Closed))
+	if len(r.Closed) == 0 {
+		b.WriteString("Nothing closed in this period.\n\n")
+	}
+	for _, issue := range r.Closed {
+		fmt.Fprintf(&b, "- **%s** %s _(%s, closed %s)_\n",
+			issue.ID, inline(issue.Title), issue.Priority, closedAt(issue).Format("Jan 2"))
+	}
+	if len(r.Closed) > 0 {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## Blocked (%d)\n\n", len(r.Blocked))
+	if len(r.Blocked) == 0 {
+		b.WriteString("No blocked issues.\n\n")
+	}
+	for _, blocker := range r.Blocked {
+		fmt.Fprintf(&b, "- **%s** %s _(%s)_", blocker.Issue.ID, inline(blocker.Issue.Title), blocker.Issue.Priority)
+		if len(blocker.WaitingOn) > 0 {
+			ids := make([]string, len(blocker.WaitingOn))
+			for i, dep := range blocker.WaitingOn {
+				ids[i] = dep.ID
+			}
+			fmt.Fprintf(&b, ": waiting on %s", strings.Join(ids, ", "))
+		}
+		b.WriteString("\n")
+	}
+	if len(r.Blocked) > 0 {
+		b.WriteString("\n")
+	}
+
+	if len(r.Convoys) > 0 {
+		b.WriteString("## Convoys\n\n")
+		b.WriteString("| Convoy | Status | Done | Blocked |\n|--------|--------|-----:|--------:|\n")
+		for _, c := range r.Convoys {
+			fmt.Fprintf(&b, "| %s (%s) | %s | %d / %d | %d |\n",
+				cell(c.Title), cell(c.ID), c.Status, c.Completed, c.Total, c.Blocked)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Agents) > 0 {
+		counts := make(map[gastown.AgentStatus]int)
+		var working []gastown.Agent
+		for _, a := range r.Agents {
+			counts[a.Status]++
+			if a.HookBead != "" || a.Molecule != "" {
+				working = append(working, a)
+			}
+		}
+
+		b.WriteString("## Agents\n\n")
+		var parts []string
+		for _, status := range []gastown.AgentStatus{gastown.StatusActive, gastown.StatusIdle, gastown.StatusStuck, gastown.StatusOffline} {
+			if counts[status] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+			}
+		}
+		fmt.Fprintf(&b, "%d agents: %s.\n\n", len(r.Agents), strings.Join(parts, ", "))
+
+		if len(working) > 0 {
+			b.WriteString("| Agent | Status | Working on |\n|-------|--------|------------|\n")
+			for _, a := range working {
+				work := a.HookBead
+				if work == "" {
+					work = a.Molecule
+				}
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", cell(a.Address()), a.Status, cell(work))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// inline flattens text onto one line.
+func inline(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(inline(s), "|", `\|`)
+}
@@ -625,13 +625,19 @@ func (a *FSAdapter) enrichAgent(agent *Agent, sessions map[string]bool) {
 	if data, err := os.ReadFile(hookPath); err == nil {
 		var hook struct {
 			Molecule string `json:"molecule,omitempty"`
+			Bead     string `json:"bead,omitempty"`
+			Issue    string `json:"issue,omitempty"`
 			Attached bool   `json:"attached,omitempty"`
 		}
 		if json.Unmarshal(data, &hook) == nil {
-			agent.HookAttached = hook.Attached || hook.Molecule != ""
+			agent.HookAttached = hook.Attached || hook.Molecule != "" || hook.Bead != "" || hook.Issue != ""
 			if hook.Molecule != "" {
 				agent.Molecule = hook.Molecule
 			}
+			agent.HookBead = hook.Bead
+			if agent.HookBead == "" {
+				agent.HookBead = hook.Issue
+			}
 		}
 	}
 
@@ -731,6 +737,8 @@ func (a *FSAdapter) parseMoleculeFile(path string) (*Molecule, error) {
 		Title       string `json:"title"`
 		Status      string `json:"status"`
 		Formula     string `json:"formula,omitempty"`
+		Issue       string `json:"issue,omitempty"`
+		Bead        string `json:"bead,omitempty"`
 		CurrentStep int    `json:"current_step"`
 		Steps       []struct {
 			Index       int        `json:"index"`
@@ -771,11 +779,16 @@ func (a *FSAdapter) parseMoleculeFile(path string) (*Molecule, error) {
 		Title:       raw.Title,
 		Status:      status,
 		Formula:     raw.Formula,
+		Issue:       raw.Issue,
 		CurrentStep: raw.CurrentStep,
 		CreatedAt:   raw.CreatedAt,
 		UpdatedAt:   raw.UpdatedAt,
 	}
 
+	if mol.Issue == "" {
+		mol.Issue = raw.Bead
+	}
+
 	// Convert steps
 	for _, s := range raw.Steps {
 		mol.Steps = append(mol.Steps, MoleculeStep{
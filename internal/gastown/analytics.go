@@ -0,0 +1,276 @@
+package gastown
+
+import (
+	"sort"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/metrics"
+)
+
+// StepTiming describes how long a molecule step took and how long it waited.
+type StepTiming struct {
+	ID              string     `json:"id"`
+	Index           int        `json:"index"`
+	Status          string     `json:"status"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	ReadyAt         *time.Time `json:"ready_at,omitempty"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	WaitSeconds     float64    `json:"wait_seconds"`
+	QueueSeconds    float64    `json:"queue_seconds"`
+	Critical        bool       `json:"critical"`
+}
+
+// MoleculeTiming is the timing analysis of a single molecule.
+type MoleculeTiming struct {
+	MoleculeID          string       `json:"molecule_id"`
+	Formula             string       `json:"formula,omitempty"`
+	Steps               []StepTiming `json:"steps"`
+	CriticalPath        []string     `json:"critical_path"`
+	CriticalPathSeconds float64      `json:"critical_path_seconds"`
+	ElapsedSeconds      float64      `json:"elapsed_seconds"`
+}
+
+// AnalyzeMolecule computes per-step durations, time spent waiting on needs
+// and the critical path of a molecule.
+//
+// A step's duration runs from StartedAt to CompletedAt, or to now while the
+// step is still running. A step becomes ready once every step it needs has
+// completed; the time before that is its wait, and the time between ready
+// and started is its queue delay. The critical path is the chain of needs
+// with the largest total duration.
+func AnalyzeMolecule(mol *Molecule, now time.Time) MoleculeTiming {
+	timing := MoleculeTiming{
+		MoleculeID:   mol.ID,
+		Formula:      mol.Formula,
+		Steps:        make([]StepTiming, 0, len(mol.Steps)),
+		CriticalPath: []string{},
+	}
+
+	origin := moleculeOrigin(mol)
+	byID := stepsByID(mol.Steps)
+
+	for _, step := range mol.Steps {
+		st := StepTiming{
+			ID:          step.ID,
+			Index:       step.Index,
+			Status:      step.Status,
+			StartedAt:   step.StartedAt,
+			CompletedAt: step.CompletedAt,
+		}
+
+		if step.StartedAt != nil {
+			end := now
+			if step.CompletedAt != nil {
+				end = *step.CompletedAt
+			}
+			st.DurationSeconds = metrics.Seconds(end.Sub(*step.StartedAt))
+		}
+
+		ready, satisfied := readyTime(step, byID, origin)
+		if satisfied && !ready.IsZero() {
+			st.ReadyAt = &ready
+		}
+		if len(step.Needs) > 0 && !origin.IsZero() {
+			waitEnd := now
+			if satisfied {
+				waitEnd = ready
+			}
+			st.WaitSeconds = metrics.Seconds(waitEnd.Sub(origin))
+		}
+		if st.ReadyAt != nil && step.StartedAt != nil {
+			st.QueueSeconds = metrics.Seconds(step.StartedAt.Sub(*st.ReadyAt))
+		}
+
+		timing.Steps = append(timing.Steps, st)
+	}
+
+	timing.CriticalPath, timing.CriticalPathSeconds = criticalPath(mol.Steps, timing.Steps)
+	critical := make(map[string]bool, len(timing.CriticalPath))
+	for _, id := range timing.CriticalPath {
+		critical[id] = true
+	}
+	for i := range timing.Steps {
+		timing.Steps[i].Critical = critical[timing.Steps[i].ID]
+	}
+
+	if !origin.IsZero() {
+		end := now
+		if last := lastCompletion(mol); mol.Status == MolStatusComplete && !last.IsZero() {
+			end = last
+		}
+		timing.ElapsedSeconds = metrics.Seconds(end.Sub(origin))
+	}
+
+	return timing
+}
+
+// moleculeOrigin returns when the molecule started: its creation time, or
+// the earliest step start if creation time was not recorded.
+func moleculeOrigin(mol *Molecule) time.Time {
+	if !mol.CreatedAt.IsZero() {
+		return mol.CreatedAt
+	}
+	var origin time.Time
+	for _, s := range mol.Steps {
+		if s.StartedAt != nil && (origin.IsZero() || s.StartedAt.Before(origin)) {
+			origin = *s.StartedAt
+		}
+	}
+	return origin
+}
+
+// lastCompletion returns the latest step completion time.
+func lastCompletion(mol *Molecule) time.Time {
+	var last time.Time
+	for _, s := range mol.Steps {
+		if s.CompletedAt != nil && s.CompletedAt.After(last) {
+			last = *s.CompletedAt
+		}
+	}
+	return last
+}
+
+// readyTime returns when all of a step's needs were completed. The second
+// return value is false while any need is still outstanding.
+func readyTime(step MoleculeStep, byID map[string]MoleculeStep, origin time.Time) (time.Time, bool) {
+	if len(step.Needs) == 0 {
+		return origin, true
+	}
+	var ready time.Time
+	for _, need := range step.Needs {
+		dep, ok := byID[need]
+		if !ok {
+			continue
+		}
+		if dep.CompletedAt == nil {
+			return time.Time{}, false
+		}
+		if dep.CompletedAt.After(ready) {
+			ready = *dep.CompletedAt
+		}
+	}
+	if ready.IsZero() {
+		return origin, true
+	}
+	return ready, true
+}
+
+// criticalPath finds the chain of needs with the largest total duration.
+func criticalPath(steps []MoleculeStep, timings []StepTiming) ([]string, float64) {
+	duration := make(map[string]float64, len(timings))
+	for _, t := range timings {
+		duration[t.ID] = t.DurationSeconds
+	}
+	byID := stepsByID(steps)
+
+	total := make(map[string]float64, len(steps))
+	prev := make(map[string]string, len(steps))
+	var end string
+	best := -1.0
+
+	for _, id := range topoSort(steps) {
+		longest := 0.0
+		for _, need := range byID[id].Needs {
+			t, ok := total[need]
+			if !ok {
+				continue
+			}
+			if prev[id] == "" || t > longest {
+				longest = t
+				prev[id] = need
+			}
+		}
+		total[id] = longest + duration[id]
+		if total[id] > best {
+			best = total[id]
+			end = id
+		}
+	}
+
+	if end == "" {
+		return []string{}, 0
+	}
+
+	var path []string
+	for id := end; id != ""; id = prev[id] {
+		path = append([]string{id}, path...)
+	}
+	return path, best
+}
+
+// FormulaStepStats aggregates durations of one step across molecules.
+type FormulaStepStats struct {
+	StepID        string  `json:"step_id"`
+	Samples       int     `json:"samples"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P95Seconds    float64 `json:"p95_seconds"`
+	MaxSeconds    float64 `json:"max_seconds"`
+}
+
+// FormulaStats aggregates step timings for every molecule poured from a formula.
+type FormulaStats struct {
+	Formula   string             `json:"formula"`
+	Molecules int                `json:"molecules"`
+	Steps     []FormulaStepStats `json:"steps"`
+}
+
+// ComputeFormulaStats groups molecules by formula and reports the median and
+// 95th percentile duration of each step. Only completed steps contribute
+// samples; molecules without a formula are skipped.
+func ComputeFormulaStats(molecules []Molecule) []FormulaStats {
+	type formulaSamples struct {
+		molecules int
+		order     []string
+		durations map[string][]float64
+	}
+
+	byFormula := make(map[string]*formulaSamples)
+	for _, mol := range molecules {
+		if mol.Formula == "" {
+			continue
+		}
+		fs, ok := byFormula[mol.Formula]
+		if !ok {
+			fs = &formulaSamples{durations: make(map[string][]float64)}
+			byFormula[mol.Formula] = fs
+		}
+		fs.molecules++
+
+		for _, step := range mol.Steps {
+			if _, seen := fs.durations[step.ID]; !seen {
+				fs.order = append(fs.order, step.ID)
+				fs.durations[step.ID] = nil
+			}
+			if step.StartedAt == nil || step.CompletedAt == nil {
+				continue
+			}
+			fs.durations[step.ID] = append(fs.durations[step.ID],
+				metrics.Seconds(step.CompletedAt.Sub(*step.StartedAt)))
+		}
+	}
+
+	stats := make([]FormulaStats, 0, len(byFormula))
+	for formula, fs := range byFormula {
+		entry := FormulaStats{
+			Formula:   formula,
+			Molecules: fs.molecules,
+			Steps:     make([]FormulaStepStats, 0, len(fs.order)),
+		}
+		for _, id := range fs.order {
+			samples := fs.durations[id]
+			sort.Float64s(samples)
+			step := FormulaStepStats{StepID: id, Samples: len(samples)}
+			if len(samples) > 0 {
+				step.MedianSeconds = metrics.Percentile(samples, 50)
+				step.P95Seconds = metrics.Percentile(samples, 95)
+				step.MaxSeconds = samples[len(samples)-1]
+			}
+			entry.Steps = append(entry.Steps, step)
+		}
+		stats = append(stats, entry)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Formula < stats[j].Formula })
+	return stats
+}
@@ -0,0 +1,133 @@
+package gastown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func at(base time.Time, minutes int) *time.Time {
+	t := base.Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+func testMolecule(base time.Time) *Molecule {
+	// plan -> (build, docs) -> ship
+	return &Molecule{
+		ID:        "mol-1",
+		Formula:   "release",
+		Status:    MolStatusInProgress,
+		CreatedAt: base,
+		Steps: []MoleculeStep{
+			{Index: 0, ID: "plan", Status: "complete", StartedAt: at(base, 0), CompletedAt: at(base, 10)},
+			{Index: 1, ID: "build", Status: "complete", Needs: []string{"plan"}, StartedAt: at(base, 15), CompletedAt: at(base, 45)},
+			{Index: 2, ID: "docs", Status: "complete", Needs: []string{"plan"}, StartedAt: at(base, 10), CompletedAt: at(base, 20)},
+			{Index: 3, ID: "ship", Status: "in_progress", Needs: []string{"build", "docs"}, StartedAt: at(base, 50)},
+		},
+	}
+}
+
+func TestMoleculeGraph(t *testing.T) {
+	mol := testMolecule(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+
+	graph := mol.Graph()
+
+	if graph.Stats.NodeCount != 4 {
+		t.Errorf("expected 4 nodes, got %d", graph.Stats.NodeCount)
+	}
+	if graph.Stats.EdgeCount != 4 {
+		t.Errorf("expected 4 edges, got %d", graph.Stats.EdgeCount)
+	}
+	if graph.Stats.MaxDepth != 3 {
+		t.Errorf("expected max depth 3, got %d", graph.Stats.MaxDepth)
+	}
+	if graph.Nodes[3].Status != model.StatusInProgress {
+		t.Errorf("expected ship in_progress, got %s", graph.Nodes[3].Status)
+	}
+	if e := graph.Edges[0]; e.From != "plan" || e.To != "build" {
+		t.Errorf("expected edge plan->build, got %s->%s", e.From, e.To)
+	}
+}
+
+func TestAnalyzeMolecule(t *testing.T) {
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	mol := testMolecule(base)
+
+	timing := AnalyzeMolecule(mol, base.Add(60*time.Minute))
+
+	byID := make(map[string]StepTiming)
+	for _, s := range timing.Steps {
+		byID[s.ID] = s
+	}
+
+	if d := byID["build"].DurationSeconds; d != 30*60 {
+		t.Errorf("expected build duration 1800s, got %v", d)
+	}
+	if d := byID["ship"].DurationSeconds; d != 10*60 {
+		t.Errorf("expected running ship duration 600s, got %v", d)
+	}
+	if w := byID["ship"].WaitSeconds; w != 45*60 {
+		t.Errorf("expected ship wait 2700s, got %v", w)
+	}
+	if q := byID["build"].QueueSeconds; q != 5*60 {
+		t.Errorf("expected build queue 300s, got %v", q)
+	}
+
+	want := []string{"plan", "build", "ship"}
+	if len(timing.CriticalPath) != len(want) {
+		t.Fatalf("expected critical path %v, got %v", want, timing.CriticalPath)
+	}
+	for i := range want {
+		if timing.CriticalPath[i] != want[i] {
+			t.Errorf("expected critical path %v, got %v", want, timing.CriticalPath)
+			break
+		}
+	}
+	if timing.CriticalPathSeconds != 50*60 {
+		t.Errorf("expected critical path 3000s, got %v", timing.CriticalPathSeconds)
+	}
+	if byID["docs"].Critical {
+		t.Error("expected docs to be off the critical path")
+	}
+}
+
+func TestComputeFormulaStats(t *testing.T) {
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	var molecules []Molecule
+	for i := 1; i <= 20; i++ {
+		molecules = append(molecules, Molecule{
+			ID:      "mol",
+			Formula: "release",
+			Steps: []MoleculeStep{
+				{ID: "build", StartedAt: at(base, 0), CompletedAt: at(base, i)},
+				{ID: "ship"},
+			},
+		})
+	}
+	molecules = append(molecules, Molecule{ID: "adhoc"})
+
+	stats := ComputeFormulaStats(molecules)
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 formula, got %d", len(stats))
+	}
+
+	release := stats[0]
+	if release.Molecules != 20 {
+		t.Errorf("expected 20 molecules, got %d", release.Molecules)
+	}
+	build := release.Steps[0]
+	if build.Samples != 20 {
+		t.Errorf("expected 20 samples, got %d", build.Samples)
+	}
+	if build.MedianSeconds != 10*60 {
+		t.Errorf("expected median 600s, got %v", build.MedianSeconds)
+	}
+	if build.P95Seconds != 19*60 {
+		t.Errorf("expected p95 1140s, got %v", build.P95Seconds)
+	}
+	if release.Steps[1].Samples != 0 {
+		t.Errorf("expected no samples for unfinished step, got %d", release.Steps[1].Samples)
+	}
+}
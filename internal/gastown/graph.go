@@ -0,0 +1,133 @@
+package gastown
+
+import (
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Graph returns the molecule's step DAG in the same shape as the issue
+// dependency graph. Each step is a node and each entry in Needs becomes an
+// edge from the needed step to the step that waits on it.
+func (m *Molecule) Graph() model.Graph {
+	graph := model.NewGraph()
+	known := make(map[string]bool, len(m.Steps))
+
+	for _, step := range m.Steps {
+		title := step.Description
+		if title == "" {
+			title = step.ID
+		}
+		graph.AddNode(model.GraphNode{
+			ID:     step.ID,
+			Title:  title,
+			Status: mapStepStatus(step.Status),
+		})
+		known[step.ID] = true
+	}
+
+	for _, step := range m.Steps {
+		for _, need := range step.Needs {
+			if !known[need] {
+				continue
+			}
+			graph.AddEdge(model.GraphEdge{
+				From: need,
+				To:   step.ID,
+				Type: model.EdgeTypeBlocks,
+			})
+		}
+	}
+
+	graph.Stats.MaxDepth = stepDepth(m.Steps)
+	return graph
+}
+
+// mapStepStatus converts a molecule step status to model.Status.
+func mapStepStatus(s string) model.Status {
+	switch s {
+	case "in_progress", "running":
+		return model.StatusInProgress
+	case "complete", "completed", "done":
+		return model.StatusDone
+	case "blocked", "failed":
+		return model.StatusBlocked
+	default:
+		return model.StatusPending
+	}
+}
+
+// stepDepth returns the number of steps on the longest chain of needs.
+func stepDepth(steps []MoleculeStep) int {
+	order := topoSort(steps)
+	depth := make(map[string]int, len(order))
+	byID := stepsByID(steps)
+
+	max := 0
+	for _, id := range order {
+		d := 1
+		for _, need := range byID[id].Needs {
+			if depth[need]+1 > d {
+				d = depth[need] + 1
+			}
+		}
+		depth[id] = d
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// stepsByID indexes steps by their ID.
+func stepsByID(steps []MoleculeStep) map[string]MoleculeStep {
+	byID := make(map[string]MoleculeStep, len(steps))
+	for _, s := range steps {
+		byID[s.ID] = s
+	}
+	return byID
+}
+
+// topoSort orders step IDs so every step follows the steps it needs.
+// Steps caught in a cycle are left out rather than failing the whole graph.
+func topoSort(steps []MoleculeStep) []string {
+	byID := stepsByID(steps)
+	indegree := make(map[string]int, len(steps))
+	dependents := make(map[string][]string, len(steps))
+
+	for _, s := range steps {
+		if _, ok := indegree[s.ID]; !ok {
+			indegree[s.ID] = 0
+		}
+		for _, need := range s.Needs {
+			if _, ok := byID[need]; !ok {
+				continue
+			}
+			indegree[s.ID]++
+			dependents[need] = append(dependents[need], s.ID)
+		}
+	}
+
+	// Seed in step order so the result is stable
+	var queue []string
+	for _, s := range steps {
+		if indegree[s.ID] == 0 {
+			queue = append(queue, s.ID)
+			indegree[s.ID] = -1
+		}
+	}
+
+	order := make([]string, 0, len(steps))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, next := range dependents[id] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				queue = append(queue, next)
+				indegree[next] = -1
+			}
+		}
+	}
+
+	return order
+}
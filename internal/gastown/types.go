@@ -63,6 +63,7 @@ type Agent struct {
 	Session      string      `json:"session,omitempty"`
 	Molecule     string      `json:"molecule,omitempty"`
 	HookAttached bool        `json:"hook_attached,omitempty"`
+	HookBead     string      `json:"hook_bead,omitempty"` // bead on the agent's hook
 	LastActive   time.Time   `json:"last_active,omitempty"`
 	Compaction   int         `json:"compaction,omitempty"`
 	WorkDir      string      `json:"work_dir,omitempty"`
@@ -152,10 +153,12 @@ type Molecule struct {
 	Progress    int            `json:"progress"`
 	Total       int            `json:"total"`
 	Formula     string         `json:"formula,omitempty"`
+	Issue       string         `json:"issue,omitempty"` // bead the molecule works on
 	Agent       string         `json:"agent,omitempty"`
 	Rig         string         `json:"rig,omitempty"`
 	CreatedAt   time.Time      `json:"created_at,omitempty"`
 	UpdatedAt   time.Time      `json:"updated_at,omitempty"`
+	Archived    bool           `json:"archived,omitempty"` // served from gvid's archive, no longer active
 }
 
 // MoleculeStep represents a step in a molecule workflow.
@@ -0,0 +1,150 @@
+// Package join links beads issues to the Gas Town agents working on them
+// and the convoys that contain them.
+package join
+
+import (
+	"sort"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Index maps issue IDs to the agents and convoys that reference them.
+type Index struct {
+	agents  map[string][]model.AgentRef
+	convoys map[string][]model.ConvoyRef
+}
+
+// New builds an index from a snapshot of Gas Town. An agent works on an
+// issue when the issue is on its hook, when its molecule is the issue, or
+// when its molecule was instantiated on the issue.
+func New(agents []gastown.Agent, convoys []gastown.Convoy, molecules []gastown.Molecule) *Index {
+	idx := &Index{
+		agents:  make(map[string][]model.AgentRef),
+		convoys: make(map[string][]model.ConvoyRef),
+	}
+
+	moleculeIssue := make(map[string]string, len(molecules))
+	for _, mol := range molecules {
+		if mol.Issue != "" {
+			moleculeIssue[mol.ID] = mol.Issue
+		}
+	}
+
+	for i := range agents {
+		agent := &agents[i]
+		ref := model.AgentRef{
+			Address:    agent.Address(),
+			Name:       agent.Name,
+			Role:       string(agent.Role),
+			Rig:        agent.Rig,
+			Status:     string(agent.Status),
+			Molecule:   agent.Molecule,
+			LastActive: agent.LastActive,
+		}
+
+		seen := make(map[string]bool)
+		link := func(issueID, via string) {
+			if issueID == "" || seen[issueID] {
+				return
+			}
+			seen[issueID] = true
+			r := ref
+			r.Via = via
+			idx.agents[issueID] = append(idx.agents[issueID], r)
+		}
+
+		link(agent.HookBead, model.AgentViaHook)
+		if agent.Molecule != "" {
+			link(moleculeIssue[agent.Molecule], model.AgentViaMolecule)
+			link(agent.Molecule, model.AgentViaMolecule)
+		}
+	}
+
+	for _, refs := range idx.agents {
+		sort.SliceStable(refs, func(i, j int) bool {
+			return statusRank(refs[i].Status) < statusRank(refs[j].Status)
+		})
+	}
+
+	for _, convoy := range convoys {
+		ref := model.ConvoyRef{
+			ID:       convoy.ID,
+			Title:    convoy.Title,
+			Status:   string(convoy.Status),
+			Progress: convoy.Progress,
+			Total:    convoy.Total,
+		}
+		for _, issueID := range convoy.Issues {
+			idx.convoys[issueID] = append(idx.convoys[issueID], ref)
+		}
+	}
+
+	return idx
+}
+
+// statusRank orders agents so the one most likely to be making progress
+// comes first.
+func statusRank(status string) int {
+	switch gastown.AgentStatus(status) {
+	case gastown.StatusActive:
+		return 0
+	case gastown.StatusStuck:
+		return 1
+	case gastown.StatusIdle:
+		return 2
+	case gastown.StatusOffline:
+		return 3
+	}
+	return 4
+}
+
+// Agents returns the agents working on an issue, active agents first.
+func (idx *Index) Agents(issueID string) []model.AgentRef {
+	if idx == nil {
+		return nil
+	}
+	return idx.agents[issueID]
+}
+
+// Agent returns the agent most likely to be working on an issue, or nil.
+func (idx *Index) Agent(issueID string) *model.AgentRef {
+	agents := idx.Agents(issueID)
+	if len(agents) == 0 {
+		return nil
+	}
+	agent := agents[0]
+	return &agent
+}
+
+// Convoys returns the convoys containing an issue.
+func (idx *Index) Convoys(issueID string) []model.ConvoyRef {
+	if idx == nil {
+		return nil
+	}
+	return idx.convoys[issueID]
+}
+
+// Annotate sets the agents and convoys of an issue.
+func (idx *Index) Annotate(issue *model.Issue) {
+	issue.Agents = idx.Agents(issue.ID)
+	issue.Convoys = idx.Convoys(issue.ID)
+}
+
+// AnnotateBoard sets the agent of every issue on the board.
+func (idx *Index) AnnotateBoard(board *model.Board) {
+	annotate := func(issues []model.IssueSummary) {
+		for i := range issues {
+			issues[i].Agent = idx.Agent(issues[i].ID)
+		}
+	}
+	for _, col := range board.Columns {
+		annotate(col.Issues)
+	}
+	for _, lane := range board.Swimlanes {
+		for _, col := range lane.Columns {
+			annotate(col.Issues)
+		}
+	}
+	annotate(board.Unmapped)
+}
@@ -0,0 +1,52 @@
+package join
+
+import (
+	"testing"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestIndex(t *testing.T) {
+	agents := []gastown.Agent{
+		{Role: gastown.RolePolecat, Name: "toast", Rig: "gastown", Status: gastown.StatusOffline, HookBead: "gt-1"},
+		{Role: gastown.RolePolecat, Name: "nux", Rig: "gastown", Status: gastown.StatusActive, Molecule: "mol-7"},
+		{Role: gastown.RoleCrew, Name: "max", Rig: "gastown", Status: gastown.StatusIdle, Molecule: "gt-2"},
+	}
+	molecules := []gastown.Molecule{{ID: "mol-7", Issue: "gt-1"}}
+	convoys := []gastown.Convoy{
+		{ID: "hq-cv-1", Title: "Auth", Status: gastown.ConvoyStatusInProgress, Issues: []string{"gt-1", "gt-2"}},
+	}
+
+	idx := New(agents, convoys, molecules)
+
+	got := idx.Agents("gt-1")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 agents on gt-1, got %+v", got)
+	}
+	if got[0].Name != "nux" || got[0].Via != model.AgentViaMolecule || got[0].Molecule != "mol-7" {
+		t.Errorf("expected the active agent first via its molecule, got %+v", got[0])
+	}
+	if got[1].Name != "toast" || got[1].Via != model.AgentViaHook || got[1].Address != "gastown/toast" {
+		t.Errorf("expected hooked agent second, got %+v", got[1])
+	}
+
+	if a := idx.Agent("gt-2"); a == nil || a.Name != "max" {
+		t.Errorf("expected max working on gt-2 through its molecule, got %+v", a)
+	}
+	if a := idx.Agent("gt-3"); a != nil {
+		t.Errorf("expected no agent on gt-3, got %+v", a)
+	}
+
+	issue := model.Issue{ID: "gt-2"}
+	idx.Annotate(&issue)
+	if len(issue.Convoys) != 1 || issue.Convoys[0].ID != "hq-cv-1" {
+		t.Errorf("expected gt-2 to be in convoy hq-cv-1, got %+v", issue.Convoys)
+	}
+
+	board := model.Board{Columns: []model.Column{{Issues: []model.IssueSummary{{ID: "gt-1"}, {ID: "gt-3"}}}}}
+	idx.AnnotateBoard(&board)
+	if a := board.Columns[0].Issues[0].Agent; a == nil || a.Name != "nux" {
+		t.Errorf("expected board badge for nux, got %+v", a)
+	}
+}
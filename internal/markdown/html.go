@@ -0,0 +1,134 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// ToHTML renders Markdown as HTML. All source text is escaped and only the
+// elements the renderer generates are emitted, so raw HTML in the source is
+// shown as text. Links are limited to web, mail and site-relative URLs. IDs
+// resolved by link become links to their target.
+func ToHTML(src string, link Linker) string {
+	var b strings.Builder
+	renderBlocks(&b, Parse(src), link)
+	return b.String()
+}
+
+func renderBlocks(b *strings.Builder, blocks []Block, link Linker) {
+	for _, block := range blocks {
+		switch block.Kind {
+		case BlockParagraph:
+			b.WriteString("<p>")
+			lines := strings.Split(block.Text, "\n")
+			for i, line := range lines {
+				if i > 0 {
+					b.WriteString("<br>\n")
+				}
+				renderInlines(b, ParseInline(line, link))
+			}
+			b.WriteString("</p>\n")
+
+		case BlockHeading:
+			fmt.Fprintf(b, "<h%d>", block.Level)
+			renderInlines(b, ParseInline(block.Text, link))
+			fmt.Fprintf(b, "</h%d>\n", block.Level)
+
+		case BlockCode:
+			b.WriteString("<pre><code")
+			if block.Lang != "" {
+				fmt.Fprintf(b, ` class="language-%s"`, html.EscapeString(strings.Fields(block.Lang)[0]))
+			}
+			b.WriteString(">")
+			b.WriteString(html.EscapeString(block.Text))
+			b.WriteString("</code></pre>\n")
+
+		case BlockList:
+			renderList(b, block.Items, link)
+
+		case BlockQuote:
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, block.Children, link)
+			b.WriteString("</blockquote>\n")
+
+		case BlockRule:
+			b.WriteString("<hr>\n")
+		}
+	}
+}
+
+// renderList renders list items, opening a nested list whenever an item is
+// indented deeper than the one before it.
+func renderList(b *strings.Builder, items []ListItem, link Linker) {
+	var open []string // closing tags of the open lists, innermost last
+
+	for _, item := range items {
+		level := item.Level
+		if level > len(open) {
+			level = len(open) // never skip a nesting level
+		}
+
+		for len(open) > level+1 {
+			b.WriteString("</li>\n" + open[len(open)-1] + "\n")
+			open = open[:len(open)-1]
+		}
+		if len(open) == level+1 {
+			b.WriteString("</li>\n")
+		}
+		if len(open) == level {
+			if item.Ordered {
+				if item.Number > 1 {
+					fmt.Fprintf(b, "<ol start=\"%d\">\n", item.Number)
+				} else {
+					b.WriteString("<ol>\n")
+				}
+				open = append(open, "</ol>")
+			} else {
+				b.WriteString("<ul>\n")
+				open = append(open, "</ul>")
+			}
+		}
+
+		if item.Checkbox {
+			b.WriteString(`<li class="task"><input type="checkbox" disabled`)
+			if item.Checked {
+				b.WriteString(" checked")
+			}
+			b.WriteString("> ")
+		} else {
+			b.WriteString("<li>")
+		}
+		renderInlines(b, ParseInline(item.Text, link))
+	}
+
+	for len(open) > 0 {
+		b.WriteString("</li>\n" + open[len(open)-1] + "\n")
+		open = open[:len(open)-1]
+	}
+}
+
+func renderInlines(b *strings.Builder, inlines []Inline) {
+	for _, in := range inlines {
+		switch in.Kind {
+		case InlineText:
+			b.WriteString(html.EscapeString(in.Text))
+		case InlineCode:
+			b.WriteString("<code>" + html.EscapeString(in.Text) + "</code>")
+		case InlineStrong:
+			b.WriteString("<strong>")
+			renderInlines(b, in.Children)
+			b.WriteString("</strong>")
+		case InlineEm:
+			b.WriteString("<em>")
+			renderInlines(b, in.Children)
+			b.WriteString("</em>")
+		case InlineLink:
+			fmt.Fprintf(b, `<a href="%s" rel="nofollow noopener">`, html.EscapeString(in.Href))
+			renderInlines(b, in.Children)
+			b.WriteString("</a>")
+		case InlineRef:
+			fmt.Fprintf(b, `<a href="%s" class="ref">%s</a>`, html.EscapeString(in.Href), html.EscapeString(in.Text))
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// InlineKind identifies the type of an inline element.
+type InlineKind int
+
+const (
+	InlineText InlineKind = iota
+	InlineCode
+	InlineStrong
+	InlineEm
+	InlineLink
+	InlineRef // a bead, convoy or molecule ID resolved by a Linker
+)
+
+// Inline is an inline element. Strong, Em and Link elements hold their
+// content in Children; the others hold it in Text.
+type Inline struct {
+	Kind     InlineKind
+	Text     string
+	Href     string
+	Children []Inline
+}
+
+// Linker resolves an ID-like token (such as "gvi-123") to a link target.
+// It returns false for tokens that are not known IDs.
+type Linker func(id string) (href string, ok bool)
+
+// refPattern matches ID-like tokens: a prefix and one or more hyphenated
+// parts, optionally followed by hierarchical ".N" suffixes.
+var refPattern = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z0-9]+)+(?:\.[0-9]+)*\b`)
+
+// ParseInline splits text into inline elements, resolving IDs with link.
+// A nil link leaves IDs as plain text.
+func ParseInline(text string, link Linker) []Inline {
+	p := inlineParser{link: link}
+	return p.parse(text)
+}
+
+type inlineParser struct {
+	link Linker
+}
+
+func (p *inlineParser) parse(text string) []Inline {
+	var out []Inline
+	var plain strings.Builder
+
+	flush := func() {
+		if plain.Len() > 0 {
+			out = append(out, p.refs(plain.String())...)
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_[]()#", rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				flush()
+				out = append(out, Inline{Kind: InlineCode, Text: rest[1 : end+1]})
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delim := rest[:2]
+			if end := strings.Index(rest[2:], delim); end > 0 {
+				flush()
+				out = append(out, Inline{Kind: InlineStrong, Children: p.parse(rest[2 : end+2])})
+				i += end + 4
+				continue
+			}
+
+		case (rest[0] == '*' || rest[0] == '_') && wordStart(text, i):
+			delim := rest[:1]
+			if end := strings.Index(rest[1:], delim); end > 0 && rest[1] != ' ' {
+				flush()
+				out = append(out, Inline{Kind: InlineEm, Children: p.parse(rest[1 : end+1])})
+				i += end + 2
+				continue
+			}
+
+		case rest[0] == '[':
+			if label, href, n, ok := parseLink(rest); ok {
+				flush()
+				if safeURL(href) {
+					out = append(out, Inline{Kind: InlineLink, Href: href, Children: p.parse(label)})
+				} else {
+					out = append(out, p.parse(label)...)
+				}
+				i += n
+				continue
+			}
+
+		case (strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://")) && wordStart(text, i):
+			end := strings.IndexAny(rest, " \t\n<>\"")
+			if end < 0 {
+				end = len(rest)
+			}
+			url := strings.TrimRight(rest[:end], ".,;:!?)")
+			flush()
+			out = append(out, Inline{Kind: InlineLink, Href: url, Children: []Inline{{Kind: InlineText, Text: url}}})
+			i += len(url)
+			continue
+		}
+
+		plain.WriteByte(rest[0])
+		i++
+	}
+	flush()
+
+	return out
+}
+
+// refs splits plain text around the IDs the linker resolves.
+func (p *inlineParser) refs(text string) []Inline {
+	if p.link == nil {
+		return []Inline{{Kind: InlineText, Text: text}}
+	}
+
+	var out []Inline
+	last := 0
+	for _, m := range refPattern.FindAllStringIndex(text, -1) {
+		href, ok := p.link(text[m[0]:m[1]])
+		if !ok {
+			continue
+		}
+		if m[0] > last {
+			out = append(out, Inline{Kind: InlineText, Text: text[last:m[0]]})
+		}
+		out = append(out, Inline{Kind: InlineRef, Text: text[m[0]:m[1]], Href: href})
+		last = m[1]
+	}
+	if last < len(text) {
+		out = append(out, Inline{Kind: InlineText, Text: text[last:]})
+	}
+	return out
+}
+
+// parseLink parses "[label](href)" at the start of s and returns the
+// number of bytes consumed.
+func parseLink(s string) (label, href string, n int, ok bool) {
+	mid := strings.Index(s, "](")
+	if mid < 0 {
+		return "", "", 0, false
+	}
+	end := strings.IndexByte(s[mid+2:], ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+	return s[1:mid], strings.TrimSpace(s[mid+2 : mid+2+end]), mid + 3 + end, true
+}
+
+// safeURL allows web, mail and site-relative links only, rejecting schemes
+// such as javascript: and data:. Browsers drop tabs and newlines from URLs
+// and read "\" as "/", so "/\host" and "/<tab>/host" would leave the site.
+func safeURL(href string) bool {
+	if strings.ContainsFunc(href, func(r rune) bool { return r < 0x20 || r == 0x7f }) {
+		return false
+	}
+	lower := strings.ToLower(href)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "mailto:"):
+		return true
+	case strings.HasPrefix(href, "//"), strings.HasPrefix(href, "/\\"):
+		return false
+	case strings.HasPrefix(href, "/"), strings.HasPrefix(href, "#"):
+		return true
+	}
+	return false
+}
+
+// wordStart reports whether position i in s follows a non-word character.
+func wordStart(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	c := s[i-1]
+	return !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9')
+}
+
+// PlainText returns the text content of inline elements.
+func PlainText(inlines []Inline) string {
+	var b strings.Builder
+	for _, in := range inlines {
+		if len(in.Children) > 0 {
+			b.WriteString(PlainText(in.Children))
+		} else {
+			b.WriteString(in.Text)
+		}
+	}
+	return b.String()
+}
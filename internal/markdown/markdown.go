@@ -0,0 +1,211 @@
+// Package markdown parses the Markdown subset used in bead descriptions
+// and renders it as sanitized HTML. The parsed document is also exposed so
+// other front ends, such as the TUI, can render it in their own style.
+//
+// Supported syntax: ATX headings, paragraphs, fenced code blocks, bulleted
+// and numbered lists (nested by indentation, with task checkboxes),
+// blockquotes, horizontal rules, and inline code, emphasis, links and bare
+// URLs.
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BlockKind identifies the type of a block.
+type BlockKind int
+
+const (
+	BlockParagraph BlockKind = iota
+	BlockHeading
+	BlockCode
+	BlockList
+	BlockQuote
+	BlockRule
+)
+
+// Block is a block-level element of a document.
+type Block struct {
+	Kind     BlockKind
+	Level    int        // heading level
+	Lang     string     // code block language
+	Text     string     // raw text of a paragraph, heading or code block
+	Items    []ListItem // list items
+	Children []Block    // blockquote contents
+}
+
+// ListItem is one list entry. Level is its nesting depth, starting at 0.
+type ListItem struct {
+	Level    int
+	Ordered  bool
+	Number   int
+	Checkbox bool
+	Checked  bool
+	Text     string
+}
+
+// Parse splits src into blocks.
+func Parse(src string) []Block {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	return parseBlocks(lines)
+}
+
+func parseBlocks(lines []string) []Block {
+	var blocks []Block
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, Block{Kind: BlockParagraph, Text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case isFence(trimmed):
+			flush()
+			fence := trimmed[:3]
+			block := Block{Kind: BlockCode, Lang: strings.TrimSpace(trimmed[3:])}
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			block.Text = strings.Join(code, "\n")
+			blocks = append(blocks, block)
+
+		case headingLevel(trimmed) > 0:
+			flush()
+			level := headingLevel(trimmed)
+			text := strings.TrimSpace(strings.TrimRight(trimmed[level:], "# "))
+			blocks = append(blocks, Block{Kind: BlockHeading, Level: level, Text: text})
+
+		case isRule(trimmed):
+			flush()
+			blocks = append(blocks, Block{Kind: BlockRule})
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					i--
+					break
+				}
+				t = strings.TrimPrefix(t, ">")
+				quoted = append(quoted, strings.TrimPrefix(t, " "))
+			}
+			blocks = append(blocks, Block{Kind: BlockQuote, Children: parseBlocks(quoted)})
+
+		default:
+			if _, ok := parseListItem(line); ok {
+				flush()
+				block := Block{Kind: BlockList}
+				for ; i < len(lines); i++ {
+					if item, ok := parseListItem(lines[i]); ok {
+						block.Items = append(block.Items, item)
+						continue
+					}
+					t := strings.TrimSpace(lines[i])
+					// Indented lines continue the previous item
+					if t != "" && lines[i] != strings.TrimLeft(lines[i], " \t") {
+						last := &block.Items[len(block.Items)-1]
+						last.Text += " " + t
+						continue
+					}
+					i--
+					break
+				}
+				blocks = append(blocks, block)
+				continue
+			}
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func isFence(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")
+}
+
+// headingLevel returns the level of an ATX heading, or 0.
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+func isRule(line string) bool {
+	if len(line) < 3 {
+		return false
+	}
+	c := line[0]
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] != c && line[i] != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseListItem parses a bulleted or numbered list line.
+func parseListItem(line string) (ListItem, bool) {
+	indent := 0
+	for _, r := range line {
+		if r == ' ' {
+			indent++
+		} else if r == '\t' {
+			indent += 4
+		} else {
+			break
+		}
+	}
+	rest := strings.TrimLeft(line, " \t")
+	item := ListItem{Level: indent / 2}
+
+	if len(rest) >= 2 && strings.ContainsRune("-*+", rune(rest[0])) && rest[1] == ' ' {
+		rest = rest[2:]
+	} else {
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || digits+1 >= len(rest) || (rest[digits] != '.' && rest[digits] != ')') || rest[digits+1] != ' ' {
+			return ListItem{}, false
+		}
+		item.Ordered = true
+		item.Number, _ = strconv.Atoi(rest[:digits])
+		rest = rest[digits+2:]
+	}
+
+	rest = strings.TrimSpace(rest)
+	if len(rest) >= 3 && rest[0] == '[' && rest[2] == ']' && strings.ContainsRune(" xX", rune(rest[1])) {
+		item.Checkbox = true
+		item.Checked = rest[1] != ' '
+		rest = strings.TrimSpace(rest[3:])
+	}
+	item.Text = rest
+	return item, true
+}
@@ -0,0 +1,99 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	src := "## Plan for gvi-12\n\n" +
+		"Fix the **parser** in `beads`, see [docs](https://example.com) and gvi-7.\n\n" +
+		"- [x] write tests\n" +
+		"  - [ ] nested step\n" +
+		"- plain item\n\n" +
+		"```go\nif a < b {}\n```\n\n" +
+		"> quoted <b>html</b>\n"
+
+	link := func(id string) (string, bool) {
+		if strings.HasPrefix(id, "gvi-") {
+			return "/issues/" + id, true
+		}
+		return "", false
+	}
+
+	got := ToHTML(src, link)
+
+	for _, want := range []string{
+		`<h2>Plan for <a href="/issues/gvi-12" class="ref">gvi-12</a></h2>`,
+		`<strong>parser</strong>`,
+		`<code>beads</code>`,
+		`<a href="https://example.com" rel="nofollow noopener">docs</a>`,
+		`<a href="/issues/gvi-7" class="ref">gvi-7</a>.`,
+		`<li class="task"><input type="checkbox" disabled checked> write tests`,
+		"<ul>\n<li class=\"task\"><input type=\"checkbox\" disabled> nested step</li>\n</ul>",
+		`<pre><code class="language-go">if a &lt; b {}</code></pre>`,
+		`<blockquote>`,
+		`quoted &lt;b&gt;html&lt;/b&gt;`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q\n\ngot:\n%s", want, got)
+		}
+	}
+}
+
+func TestToHTMLRejectsUnsafeLinks(t *testing.T) {
+	got := ToHTML(`[click](javascript:alert(1)) <script>alert(1)</script> [x](data:text/html,hi)`, nil)
+
+	if strings.Contains(got, "href") {
+		t.Errorf("expected unsafe links to be dropped, got %s", got)
+	}
+	if strings.Contains(got, "<script>") {
+		t.Errorf("expected raw HTML to be escaped, got %s", got)
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	tests := []struct {
+		href string
+		want bool
+	}{
+		{"https://example.com/a", true},
+		{"mailto:dev@example.com", true},
+		{"/issues/gt-1", true},
+		{"#criteria", true},
+		{"//evil.com", false},
+		{`/\evil.com`, false},
+		{`/\\evil.com`, false},
+		{"/\t/evil.com", false},
+		{"/\n/evil.com", false},
+		{"java\tscript:alert(1)", false},
+		{"javascript:alert(1)", false},
+		{"evil.com", false},
+	}
+	for _, tt := range tests {
+		if got := safeURL(tt.href); got != tt.want {
+			t.Errorf("safeURL(%q) = %v, want %v", tt.href, got, tt.want)
+		}
+	}
+	if got := ToHTML(`[x](/\evil.com)`, nil); strings.Contains(got, "href") {
+		t.Errorf("expected a backslash-relative link to be dropped, got %s", got)
+	}
+}
+
+func TestParseOrderedList(t *testing.T) {
+	blocks := Parse("3. third\n4) fourth\n   continued")
+
+	if len(blocks) != 1 || blocks[0].Kind != BlockList {
+		t.Fatalf("expected one list, got %+v", blocks)
+	}
+	items := blocks[0].Items
+	if len(items) != 2 || !items[0].Ordered || items[0].Number != 3 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if items[1].Text != "fourth continued" {
+		t.Errorf("expected the indented line to continue the item, got %q", items[1].Text)
+	}
+	if html := ToHTML("3. third", nil); !strings.Contains(html, `<ol start="3">`) {
+		t.Errorf("expected the list to start at 3, got %s", html)
+	}
+}
@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// FlowOptions controls the window and thresholds of a flow report.
+type FlowOptions struct {
+	Since          time.Time
+	Until          time.Time
+	AgingThreshold time.Duration
+}
+
+// ThroughputBucket is the number of issues closed in one period.
+type ThroughputBucket struct {
+	Period string    `json:"period"`
+	Start  time.Time `json:"start"`
+	Closed int       `json:"closed"`
+}
+
+// Throughput counts closed issues per day and per ISO week.
+type Throughput struct {
+	Total   int                `json:"total"`
+	PerDay  []ThroughputBucket `json:"per_day"`
+	PerWeek []ThroughputBucket `json:"per_week"`
+}
+
+// HistogramBucket counts samples up to an upper bound.
+type HistogramBucket struct {
+	Label      string  `json:"label"`
+	MaxSeconds float64 `json:"max_seconds,omitempty"` // zero for the open-ended last bucket
+	Count      int     `json:"count"`
+}
+
+// Distribution summarises a set of durations.
+type Distribution struct {
+	Count       int               `json:"count"`
+	MinSeconds  float64           `json:"min_seconds"`
+	MeanSeconds float64           `json:"mean_seconds"`
+	P50Seconds  float64           `json:"p50_seconds"`
+	P85Seconds  float64           `json:"p85_seconds"`
+	P95Seconds  float64           `json:"p95_seconds"`
+	MaxSeconds  float64           `json:"max_seconds"`
+	Histogram   []HistogramBucket `json:"histogram"`
+}
+
+// AgingIssue is an in-progress issue older than the aging threshold.
+type AgingIssue struct {
+	ID         string         `json:"id"`
+	Title      string         `json:"title"`
+	Priority   model.Priority `json:"priority"`
+	IssueType  string         `json:"issue_type,omitempty"`
+	Since      time.Time      `json:"since"`
+	AgeSeconds float64        `json:"age_seconds"`
+	// StartKnown is false when the start of work was not observed and the
+	// age is measured from the issue's creation instead.
+	StartKnown bool `json:"start_known"`
+}
+
+// AgingReport lists in-progress issues past the threshold, oldest first.
+type AgingReport struct {
+	ThresholdSeconds float64      `json:"threshold_seconds"`
+	Issues           []AgingIssue `json:"issues"`
+}
+
+// FlowMetrics is the flow report for a set of issues.
+type FlowMetrics struct {
+	Since      time.Time            `json:"since"`
+	Until      time.Time            `json:"until"`
+	Issues     int                  `json:"issues"`
+	Throughput Throughput           `json:"throughput"`
+	WIP        map[model.Status]int `json:"wip"`
+	CycleTime  Distribution         `json:"cycle_time"`
+	LeadTime   Distribution         `json:"lead_time"`
+	Aging      AgingReport          `json:"aging"`
+}
+
+// ComputeFlow derives throughput, work in progress, cycle and lead time
+// distributions and the aging report for issues.
+//
+// started maps issue IDs to when work on them began (their first move to
+// in_progress). Cycle time is only reported for closed issues with a known
+// start; lead time covers every issue closed in the window.
+func ComputeFlow(issues []model.Issue, started map[string]time.Time, opts FlowOptions, now time.Time) FlowMetrics {
+	flow := FlowMetrics{
+		Since:  opts.Since,
+		Until:  opts.Until,
+		Issues: len(issues),
+		WIP:    make(map[model.Status]int),
+		Aging: AgingReport{
+			ThresholdSeconds: opts.AgingThreshold.Seconds(),
+			Issues:           []AgingIssue{},
+		},
+	}
+
+	var closedTimes []time.Time
+	var cycle, lead []float64
+
+	for _, issue := range issues {
+		if issue.Status != model.StatusDone {
+			flow.WIP[issue.Status]++
+		}
+
+		if issue.Status == model.StatusInProgress {
+			since, known := started[issue.ID]
+			if !known {
+				since = issue.CreatedAt
+			}
+			if age := now.Sub(since); age >= opts.AgingThreshold {
+				flow.Aging.Issues = append(flow.Aging.Issues, AgingIssue{
+					ID:         issue.ID,
+					Title:      issue.Title,
+					Priority:   issue.Priority,
+					IssueType:  issue.IssueType,
+					Since:      since,
+					AgeSeconds: Seconds(age),
+					StartKnown: known,
+				})
+			}
+		}
+
+		if issue.Status != model.StatusDone {
+			continue
+		}
+		closed := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			closed = *issue.ClosedAt
+		}
+		if closed.Before(opts.Since) || closed.After(opts.Until) {
+			continue
+		}
+
+		closedTimes = append(closedTimes, closed)
+		lead = append(lead, Seconds(closed.Sub(issue.CreatedAt)))
+		if start, ok := started[issue.ID]; ok && !start.After(closed) {
+			cycle = append(cycle, Seconds(closed.Sub(start)))
+		}
+	}
+
+	sort.Slice(flow.Aging.Issues, func(i, j int) bool {
+		return flow.Aging.Issues[i].AgeSeconds > flow.Aging.Issues[j].AgeSeconds
+	})
+
+	flow.Throughput = throughput(closedTimes, opts.Since, opts.Until)
+	flow.CycleTime = distribution(cycle)
+	flow.LeadTime = distribution(lead)
+
+	return flow
+}
+
+// throughput buckets close times by calendar day and ISO week in the
+// location of since, including empty periods.
+func throughput(closed []time.Time, since, until time.Time) Throughput {
+	loc := since.Location()
+	tp := Throughput{
+		Total:   len(closed),
+		PerDay:  []ThroughputBucket{},
+		PerWeek: []ThroughputBucket{},
+	}
+
+	dayIndex := make(map[string]int)
+	for d := startOfDay(since); !d.After(until); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		dayIndex[key] = len(tp.PerDay)
+		tp.PerDay = append(tp.PerDay, ThroughputBucket{Period: key, Start: d})
+	}
+
+	weekIndex := make(map[string]int)
+	for w := startOfWeek(since); !w.After(until); w = w.AddDate(0, 0, 7) {
+		key := weekKey(w)
+		weekIndex[key] = len(tp.PerWeek)
+		tp.PerWeek = append(tp.PerWeek, ThroughputBucket{Period: key, Start: w})
+	}
+
+	for _, t := range closed {
+		t = t.In(loc)
+		if i, ok := dayIndex[t.Format("2006-01-02")]; ok {
+			tp.PerDay[i].Closed++
+		}
+		if i, ok := weekIndex[weekKey(t)]; ok {
+			tp.PerWeek[i].Closed++
+		}
+	}
+
+	return tp
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday starting t's ISO week.
+func startOfWeek(t time.Time) time.Time {
+	d := startOfDay(t)
+	offset := (int(d.Weekday()) + 6) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+func weekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// cycleHistogram is the upper bound of each histogram bucket.
+var cycleHistogram = []struct {
+	label string
+	max   time.Duration
+}{
+	{"<1h", time.Hour},
+	{"1h-1d", 24 * time.Hour},
+	{"1-2d", 2 * 24 * time.Hour},
+	{"2-4d", 4 * 24 * time.Hour},
+	{"4-7d", 7 * 24 * time.Hour},
+	{"1-2w", 14 * 24 * time.Hour},
+	{">2w", 0},
+}
+
+// distribution summarises durations in seconds.
+func distribution(samples []float64) Distribution {
+	dist := Distribution{Count: len(samples), Histogram: make([]HistogramBucket, len(cycleHistogram))}
+	for i, b := range cycleHistogram {
+		dist.Histogram[i] = HistogramBucket{Label: b.label, MaxSeconds: b.max.Seconds()}
+	}
+	if len(samples) == 0 {
+		return dist
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, s := range sorted {
+		sum += s
+		for i, b := range cycleHistogram {
+			if b.max == 0 || s < b.max.Seconds() {
+				dist.Histogram[i].Count++
+				break
+			}
+		}
+	}
+
+	dist.MinSeconds = sorted[0]
+	dist.MaxSeconds = sorted[len(sorted)-1]
+	dist.MeanSeconds = sum / float64(len(sorted))
+	dist.P50Seconds = Percentile(sorted, 50)
+	dist.P85Seconds = Percentile(sorted, 85)
+	dist.P95Seconds = Percentile(sorted, 95)
+	return dist
+}
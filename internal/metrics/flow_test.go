@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestComputeFlow(t *testing.T) {
+	// Wednesday; the window covers Mon 5 Jan to Wed 14 Jan 2026
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	since := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	closedAt := func(d time.Time) *time.Time { return &d }
+
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusDone, CreatedAt: since, ClosedAt: closedAt(since.Add(2 * day))},
+		{ID: "b", Status: model.StatusDone, CreatedAt: since, ClosedAt: closedAt(since.Add(2*day + time.Hour))},
+		{ID: "c", Status: model.StatusDone, CreatedAt: since, UpdatedAt: since.Add(8 * day)},
+		{ID: "old", Status: model.StatusDone, CreatedAt: since.Add(-30 * day), ClosedAt: closedAt(since.Add(-day))},
+		{ID: "stale", Status: model.StatusInProgress, CreatedAt: since},
+		{ID: "fresh", Status: model.StatusInProgress, CreatedAt: since},
+		{ID: "todo", Status: model.StatusPending, CreatedAt: since},
+	}
+	started := map[string]time.Time{
+		"a":     since.Add(day),
+		"fresh": now.Add(-time.Hour),
+	}
+
+	flow := ComputeFlow(issues, started, FlowOptions{Since: since, Until: now, AgingThreshold: 3 * day}, now)
+
+	if flow.Throughput.Total != 3 {
+		t.Errorf("expected 3 closed in window, got %d", flow.Throughput.Total)
+	}
+	if len(flow.Throughput.PerDay) != 10 {
+		t.Fatalf("expected 10 days including empty ones, got %d", len(flow.Throughput.PerDay))
+	}
+	if got := flow.Throughput.PerDay[2].Closed; got != 2 {
+		t.Errorf("expected 2 closed on 7 Jan, got %d", got)
+	}
+	if len(flow.Throughput.PerWeek) != 2 || flow.Throughput.PerWeek[0].Closed != 2 || flow.Throughput.PerWeek[1].Closed != 1 {
+		t.Errorf("unexpected weekly throughput: %+v", flow.Throughput.PerWeek)
+	}
+	if flow.Throughput.PerWeek[0].Period != "2026-W02" {
+		t.Errorf("expected ISO week 2026-W02, got %s", flow.Throughput.PerWeek[0].Period)
+	}
+
+	if flow.WIP[model.StatusInProgress] != 2 || flow.WIP[model.StatusPending] != 1 {
+		t.Errorf("unexpected WIP: %v", flow.WIP)
+	}
+	if _, ok := flow.WIP[model.StatusDone]; ok {
+		t.Error("expected done issues to be excluded from WIP")
+	}
+
+	if flow.CycleTime.Count != 1 || flow.CycleTime.P50Seconds != day.Seconds() {
+		t.Errorf("expected one 1d cycle time, got %+v", flow.CycleTime)
+	}
+	if flow.LeadTime.Count != 3 || flow.LeadTime.MaxSeconds != (8*day).Seconds() {
+		t.Errorf("unexpected lead time distribution: %+v", flow.LeadTime)
+	}
+
+	if len(flow.Aging.Issues) != 1 || flow.Aging.Issues[0].ID != "stale" {
+		t.Fatalf("expected only the stale issue to be aging, got %+v", flow.Aging.Issues)
+	}
+	if flow.Aging.Issues[0].StartKnown {
+		t.Error("expected the aging start to fall back to creation")
+	}
+}
+
+func TestDistributionPercentiles(t *testing.T) {
+	var samples []float64
+	for i := 1; i <= 20; i++ {
+		samples = append(samples, float64(i)*3600)
+	}
+
+	dist := distribution(samples)
+
+	if dist.P50Seconds != 10*3600 || dist.P85Seconds != 17*3600 || dist.P95Seconds != 19*3600 {
+		t.Errorf("unexpected percentiles: p50=%v p85=%v p95=%v", dist.P50Seconds, dist.P85Seconds, dist.P95Seconds)
+	}
+	if dist.Histogram[0].Count != 0 || dist.Histogram[1].Count != 20 {
+		t.Errorf("expected every sample in the 1h-1d bucket, got %+v", dist.Histogram)
+	}
+}
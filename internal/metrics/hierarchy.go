@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// EstimateWindow is how far back closures are counted when projecting an
+// epic's completion.
+const EstimateWindow = 28 * 24 * time.Hour
+
+// Hierarchy indexes the parent/child relationships between issues.
+type Hierarchy struct {
+	issues   map[string]model.Issue
+	children map[string][]string
+	parent   map[string]string
+}
+
+// BuildHierarchy indexes issues by parent. Parents come from parent-child
+// dependencies in either direction; issues with hierarchical IDs such as
+// "gt-abc.1" fall back to the issue their ID extends.
+func BuildHierarchy(issues []model.Issue) *Hierarchy {
+	h := &Hierarchy{
+		issues:   make(map[string]model.Issue, len(issues)),
+		children: make(map[string][]string),
+		parent:   make(map[string]string),
+	}
+	for _, issue := range issues {
+		h.issues[issue.ID] = issue
+	}
+
+	link := func(parent, child string) {
+		if parent == child {
+			return
+		}
+		if _, ok := h.issues[parent]; !ok {
+			return
+		}
+		if _, ok := h.issues[child]; !ok {
+			return
+		}
+		if _, ok := h.parent[child]; ok {
+			return
+		}
+		h.parent[child] = parent
+		h.children[parent] = append(h.children[parent], child)
+	}
+
+	for _, issue := range issues {
+		if issue.Parent != nil {
+			link(issue.Parent.ID, issue.ID)
+		}
+		for _, child := range issue.Children {
+			link(issue.ID, child.ID)
+		}
+	}
+	for _, issue := range issues {
+		if i := strings.LastIndex(issue.ID, "."); i > 0 {
+			link(issue.ID[:i], issue.ID)
+		}
+	}
+
+	for _, ids := range h.children {
+		sort.Strings(ids)
+	}
+	return h
+}
+
+// Tree returns the issue with its descendants and rollups, or false if the
+// issue is unknown.
+func (h *Hierarchy) Tree(id string, now time.Time) (model.IssueTree, bool) {
+	issue, ok := h.issues[id]
+	if !ok {
+		return model.IssueTree{}, false
+	}
+	tree, _ := h.build(issue, now, map[string]bool{})
+	return tree, true
+}
+
+// Epics returns the trees of all epics: issues of type epic, and top-level
+// issues that have children. Epics are ordered by priority, then ID.
+func (h *Hierarchy) Epics(now time.Time) []model.IssueTree {
+	var ids []string
+	for id, issue := range h.issues {
+		_, hasParent := h.parent[id]
+		if issue.IssueType == "epic" || (!hasParent && len(h.children[id]) > 0) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := h.issues[ids[i]], h.issues[ids[j]]
+		if a.Priority.Level() != b.Priority.Level() {
+			return a.Priority.Level() < b.Priority.Level()
+		}
+		return a.ID < b.ID
+	})
+
+	epics := make([]model.IssueTree, 0, len(ids))
+	for _, id := range ids {
+		tree, _ := h.Tree(id, now)
+		epics = append(epics, tree)
+	}
+	return epics
+}
+
+// build constructs the tree under issue and returns it with the close
+// times of its descendants. visited guards against cyclic parent links.
+func (h *Hierarchy) build(issue model.Issue, now time.Time, visited map[string]bool) (model.IssueTree, []time.Time) {
+	visited[issue.ID] = true
+	tree := model.IssueTree{
+		IssueSummary: issue.Summary(),
+		Children:     []model.IssueTree{},
+	}
+
+	var closed []time.Time
+	for _, childID := range h.children[issue.ID] {
+		if visited[childID] {
+			continue
+		}
+		child := h.issues[childID]
+		subtree, childClosed := h.build(child, now, visited)
+		tree.Children = append(tree.Children, subtree)
+
+		r := &tree.Rollup
+		r.Total += 1 + subtree.Rollup.Total
+		r.Done += subtree.Rollup.Done
+		r.InProgress += subtree.Rollup.InProgress
+		r.Blocked += subtree.Rollup.Blocked
+		r.HighestOpenPriority = higherPriority(r.HighestOpenPriority, subtree.Rollup.HighestOpenPriority)
+		r.Criteria = addCriteria(r.Criteria, child.CriteriaProgress)
+		r.Criteria = addCriteria(r.Criteria, subtree.Rollup.Criteria)
+
+		switch child.Status {
+		case model.StatusDone:
+			r.Done++
+			closedAt := child.UpdatedAt
+			if child.ClosedAt != nil {
+				closedAt = *child.ClosedAt
+			}
+			childClosed = append(childClosed, closedAt)
+		case model.StatusInProgress:
+			r.InProgress++
+		case model.StatusBlocked:
+			r.Blocked++
+		}
+		if child.Status != model.StatusDone {
+			r.HighestOpenPriority = higherPriority(r.HighestOpenPriority, child.Priority)
+		}
+		closed = append(closed, childClosed...)
+	}
+
+	if tree.Rollup.Total > 0 {
+		tree.Rollup.Progress = float64(tree.Rollup.Done) / float64(tree.Rollup.Total)
+	}
+	tree.Rollup.Estimate = estimate(tree.Rollup.Total-tree.Rollup.Done, closed, now)
+
+	return tree, closed
+}
+
+// addCriteria adds other to the running total, allocating it on first use.
+func addCriteria(total, other *model.CriteriaProgress) *model.CriteriaProgress {
+	if other == nil {
+		return total
+	}
+	if total == nil {
+		total = &model.CriteriaProgress{}
+	}
+	total.Add(other)
+	return total
+}
+
+// higherPriority returns the more urgent of two priorities, ignoring unset ones.
+func higherPriority(a, b model.Priority) model.Priority {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	case b.Level() >= 0 && b.Level() < a.Level():
+		return b
+	}
+	return a
+}
+
+// estimate projects completion of remaining items from the number closed
+// during the trailing EstimateWindow. It returns nil when nothing remains
+// or nothing was closed recently.
+func estimate(remaining int, closed []time.Time, now time.Time) *model.CompletionEstimate {
+	if remaining <= 0 {
+		return nil
+	}
+
+	since := now.Add(-EstimateWindow)
+	recent := 0
+	for _, t := range closed {
+		if t.After(since) && !t.After(now) {
+			recent++
+		}
+	}
+	if recent == 0 {
+		return nil
+	}
+
+	days := EstimateWindow.Hours() / 24
+	perDay := float64(recent) / days
+	return &model.CompletionEstimate{
+		Remaining:    remaining,
+		ClosedPerDay: perDay,
+		WindowDays:   int(days),
+		CompletesAt:  now.Add(time.Duration(float64(remaining) / perDay * float64(24*time.Hour))),
+	}
+}
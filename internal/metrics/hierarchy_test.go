@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestHierarchyRollup(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	closed := now.Add(-7 * 24 * time.Hour)
+
+	issues := []model.Issue{
+		{ID: "gt-epic", IssueType: "epic", Status: model.StatusInProgress, Priority: model.PriorityMedium,
+			Children: []model.IssueSummary{{ID: "gt-a"}, {ID: "gt-b"}}},
+		{ID: "gt-a", Status: model.StatusDone, Priority: model.PriorityCritical, ClosedAt: &closed,
+			CriteriaProgress: &model.CriteriaProgress{Total: 2, Checked: 1}},
+		{ID: "gt-b", Status: model.StatusBlocked, Priority: model.PriorityLow,
+			Parent: &model.IssueSummary{ID: "gt-epic"}},
+		// Child of gt-b by hierarchical ID only
+		{ID: "gt-b.1", Status: model.StatusPending, Priority: model.PriorityHigh,
+			CriteriaProgress: &model.CriteriaProgress{Total: 2, Checked: 2}},
+		{ID: "gt-lone", Status: model.StatusPending},
+	}
+
+	h := BuildHierarchy(issues)
+
+	tree, ok := h.Tree("gt-epic", now)
+	if !ok {
+		t.Fatal("expected epic tree")
+	}
+
+	r := tree.Rollup
+	if r.Total != 3 || r.Done != 1 || r.Blocked != 1 {
+		t.Errorf("expected 3 descendants, 1 done, 1 blocked, got %+v", r)
+	}
+	if r.HighestOpenPriority != model.PriorityHigh {
+		t.Errorf("expected the grandchild's high priority to roll up past the done critical child, got %q", r.HighestOpenPriority)
+	}
+	if r.Criteria == nil || r.Criteria.Total != 4 || r.Criteria.Percent != 75 {
+		t.Errorf("expected 3 of 4 criteria checked across descendants, got %+v", r.Criteria)
+	}
+	if len(tree.Children) != 2 || len(tree.Children[1].Children) != 1 {
+		t.Fatalf("unexpected tree shape: %+v", tree.Children)
+	}
+
+	if r.Estimate == nil {
+		t.Fatal("expected a completion estimate from the recent close")
+	}
+	if r.Estimate.Remaining != 2 || !r.Estimate.CompletesAt.Equal(now.Add(56*24*time.Hour)) {
+		t.Errorf("expected 2 remaining completing in 56 days, got %+v", r.Estimate)
+	}
+
+	epics := h.Epics(now)
+	if len(epics) != 1 || epics[0].ID != "gt-epic" {
+		t.Errorf("expected only gt-epic to be listed, got %d epics", len(epics))
+	}
+
+	if _, ok := h.Tree("missing", now); ok {
+		t.Error("expected no tree for an unknown issue")
+	}
+}
+
+func TestHierarchyIgnoresCycles(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Parent: &model.IssueSummary{ID: "b"}},
+		{ID: "b", Parent: &model.IssueSummary{ID: "a"}},
+	}
+
+	tree, ok := BuildHierarchy(issues).Tree("a", time.Now())
+	if !ok {
+		t.Fatal("expected a tree")
+	}
+	if tree.Rollup.Total != 1 || len(tree.Children[0].Children) != 0 {
+		t.Errorf("expected the cycle to be cut after one level, got %+v", tree)
+	}
+}
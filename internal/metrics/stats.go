@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"math"
+	"time"
+)
+
+// Percentile returns the nearest-rank percentile of sorted samples.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Seconds converts a duration to seconds, clamping negative values to zero.
+func Seconds(d time.Duration) float64 {
+	if d < 0 {
+		return 0
+	}
+	return d.Seconds()
+}
@@ -0,0 +1,116 @@
+// Package metrics derives flow metrics such as timelines, lead and cycle
+// times from issue timestamps and recorded status history.
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// BuildTimeline reconstructs an issue's status history from status change
+// entries (from bd's event log or gvid's observations) and the issue's own
+// timestamps, then derives lead, cycle and wait times.
+//
+// Entries whose From is empty (a first observation) are treated as a change
+// from the status in effect at that time. Entries that don't change the
+// status are dropped. If the entries end in a different status than the
+// issue's current one, a closing entry is derived from ClosedAt or
+// UpdatedAt so the timeline always ends in the current status.
+func BuildTimeline(issue model.Issue, entries []model.TimelineEntry, source model.TimelineSource, now time.Time) model.Timeline {
+	sorted := make([]model.TimelineEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	tl := model.Timeline{
+		IssueID:      issue.ID,
+		Source:       source,
+		CreatedAt:    issue.CreatedAt,
+		TimeInStatus: make(map[model.Status]float64),
+	}
+
+	// Issues are created pending; a matching created event from bd replaces the derived one
+	current := model.StatusPending
+	tl.Entries = append(tl.Entries, model.TimelineEntry{
+		Time:   issue.CreatedAt,
+		To:     model.StatusPending,
+		Source: model.TimelineSourceIssue,
+	})
+
+	for _, e := range sorted {
+		if e.Time.Before(issue.CreatedAt) {
+			e.Time = issue.CreatedAt
+		}
+		if e.From == "" {
+			if len(tl.Entries) == 1 && e.Source == model.TimelineSourceBD && e.Time.Equal(issue.CreatedAt) {
+				// bd's own created event: keep its actor and status
+				tl.Entries[0] = e
+				current = e.To
+				continue
+			}
+			e.From = current
+		}
+		if e.To == current {
+			continue
+		}
+		tl.Entries = append(tl.Entries, e)
+		current = e.To
+	}
+
+	if current != issue.Status {
+		at := issue.UpdatedAt
+		if issue.Status == model.StatusDone && issue.ClosedAt != nil {
+			at = *issue.ClosedAt
+		}
+		if last := tl.Entries[len(tl.Entries)-1].Time; at.Before(last) {
+			at = last
+		}
+		tl.Entries = append(tl.Entries, model.TimelineEntry{
+			Time:   at,
+			From:   current,
+			To:     issue.Status,
+			Source: model.TimelineSourceIssue,
+		})
+	}
+
+	for i, e := range tl.Entries {
+		if e.To == model.StatusInProgress && tl.StartedAt == nil {
+			t := e.Time
+			tl.StartedAt = &t
+		}
+		end := now
+		if i+1 < len(tl.Entries) {
+			end = tl.Entries[i+1].Time
+		} else if e.To == model.StatusDone {
+			continue
+		}
+		tl.TimeInStatus[e.To] += Seconds(end.Sub(e.Time))
+	}
+
+	if issue.Status == model.StatusDone {
+		closed := tl.Entries[len(tl.Entries)-1].Time
+		if issue.ClosedAt != nil {
+			closed = *issue.ClosedAt
+		}
+		tl.ClosedAt = &closed
+
+		lead := Seconds(closed.Sub(issue.CreatedAt))
+		tl.LeadTimeSeconds = &lead
+		if tl.StartedAt != nil {
+			cycle := Seconds(closed.Sub(*tl.StartedAt))
+			tl.CycleTimeSeconds = &cycle
+		}
+	}
+
+	waitEnd := now
+	switch {
+	case tl.StartedAt != nil:
+		waitEnd = *tl.StartedAt
+	case tl.ClosedAt != nil:
+		waitEnd = *tl.ClosedAt
+	}
+	tl.WaitTimeSeconds = Seconds(waitEnd.Sub(issue.CreatedAt))
+
+	return tl
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestBuildTimelineFromObservations(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(10 * time.Hour)
+
+	issue := model.Issue{
+		ID:        "gvi-1",
+		Status:    model.StatusDone,
+		CreatedAt: created,
+		UpdatedAt: closed,
+		ClosedAt:  &closed,
+	}
+
+	// gvid first saw the issue already blocked, then watched it move on
+	entries := []model.TimelineEntry{
+		{Time: created.Add(time.Hour), To: model.StatusBlocked, Source: model.TimelineSourceGVID},
+		{Time: created.Add(4 * time.Hour), From: model.StatusBlocked, To: model.StatusInProgress, Source: model.TimelineSourceGVID},
+	}
+
+	tl := BuildTimeline(issue, entries, model.TimelineSourceGVID, closed.Add(time.Hour))
+
+	if len(tl.Entries) != 4 {
+		t.Fatalf("expected 4 entries (created, blocked, in_progress, done), got %d: %+v", len(tl.Entries), tl.Entries)
+	}
+	if tl.Entries[1].From != model.StatusPending {
+		t.Errorf("expected first observation to be a change from pending, got %q", tl.Entries[1].From)
+	}
+	if last := tl.Entries[3]; last.To != model.StatusDone || !last.Time.Equal(closed) {
+		t.Errorf("expected derived close at %v, got %+v", closed, last)
+	}
+
+	if tl.LeadTimeSeconds == nil || *tl.LeadTimeSeconds != 10*3600 {
+		t.Errorf("expected lead time 36000s, got %v", tl.LeadTimeSeconds)
+	}
+	if tl.CycleTimeSeconds == nil || *tl.CycleTimeSeconds != 6*3600 {
+		t.Errorf("expected cycle time 21600s, got %v", tl.CycleTimeSeconds)
+	}
+	if tl.WaitTimeSeconds != 4*3600 {
+		t.Errorf("expected wait time 14400s, got %v", tl.WaitTimeSeconds)
+	}
+	if got := tl.TimeInStatus[model.StatusBlocked]; got != 3*3600 {
+		t.Errorf("expected 3h blocked, got %vs", got)
+	}
+	if _, ok := tl.TimeInStatus[model.StatusDone]; ok {
+		t.Error("expected no time accrued in the final done status")
+	}
+}
+
+func TestBuildTimelineOpenIssue(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := created.Add(5 * time.Hour)
+
+	issue := model.Issue{ID: "gvi-2", Status: model.StatusPending, CreatedAt: created, UpdatedAt: created}
+
+	tl := BuildTimeline(issue, nil, model.TimelineSourceIssue, now)
+
+	if len(tl.Entries) != 1 {
+		t.Fatalf("expected only the created entry, got %d", len(tl.Entries))
+	}
+	if tl.LeadTimeSeconds != nil || tl.CycleTimeSeconds != nil {
+		t.Error("expected no lead or cycle time for an open issue")
+	}
+	if tl.WaitTimeSeconds != 5*3600 {
+		t.Errorf("expected wait time 18000s, got %v", tl.WaitTimeSeconds)
+	}
+}
@@ -1,39 +1,296 @@
 package model
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // Column represents a status column in the board view.
 type Column struct {
-	Status Status         `json:"status"`
-	Label  string         `json:"label"`
-	Count  int            `json:"count"`
-	Issues []IssueSummary `json:"issues"`
+	ID        string         `json:"id,omitempty"`
+	Status    Status         `json:"status,omitempty"`
+	Label     string         `json:"label"`
+	Statuses  []string       `json:"statuses,omitempty"`
+	WIPLimit  int            `json:"wip_limit,omitempty"`
+	OverLimit bool           `json:"over_limit,omitempty"`
+	Count     int            `json:"count"`
+	Issues    []IssueSummary `json:"issues"`
+}
+
+// Swimlane is a horizontal slice of the board holding the issues that share
+// one value of the board's grouping, laid out in the board's columns.
+type Swimlane struct {
+	Key     string   `json:"key"`
+	Label   string   `json:"label"`
+	Count   int      `json:"count"`
+	Columns []Column `json:"columns"`
 }
 
 // Board represents the kanban board view with issues grouped by status.
 type Board struct {
-	Columns []Column `json:"columns"`
-	Total   int      `json:"total"`
+	Columns   []Column       `json:"columns"`
+	Total     int            `json:"total"`
+	GroupBy   GroupBy        `json:"group_by,omitempty"`
+	Swimlanes []Swimlane     `json:"swimlanes,omitempty"`
+	Unmapped  []IssueSummary `json:"unmapped,omitempty"`
 }
 
-// NewBoard creates an empty board with standard columns.
-func NewBoard() Board {
-	return Board{
-		Columns: []Column{
-			{Status: StatusPending, Label: "Pending", Issues: []IssueSummary{}},
-			{Status: StatusInProgress, Label: "In Progress", Issues: []IssueSummary{}},
-			{Status: StatusDone, Label: "Done", Issues: []IssueSummary{}},
-			{Status: StatusBlocked, Label: "Blocked", Issues: []IssueSummary{}},
+// ColumnConfig defines one board column. Statuses lists the bd statuses
+// (such as "open" or "deferred") or normalized statuses (such as
+// "in_progress") whose issues belong in the column.
+type ColumnConfig struct {
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Statuses []string `json:"statuses"`
+	WIPLimit int      `json:"wip_limit,omitempty"`
+}
+
+// BoardConfig defines the columns of the board.
+type BoardConfig struct {
+	Columns []ColumnConfig `json:"columns"`
+}
+
+// DefaultBoardConfig returns the standard four-column board.
+func DefaultBoardConfig() BoardConfig {
+	return BoardConfig{
+		Columns: []ColumnConfig{
+			{ID: "pending", Label: "Pending", Statuses: []string{string(StatusPending)}},
+			{ID: "in_progress", Label: "In Progress", Statuses: []string{string(StatusInProgress)}},
+			{ID: "done", Label: "Done", Statuses: []string{string(StatusDone)}},
+			{ID: "blocked", Label: "Blocked", Statuses: []string{string(StatusBlocked)}},
 		},
 	}
 }
 
-// AddIssue adds an issue summary to the appropriate column.
+// ParseBoardConfig parses and validates a JSON board definition.
+func ParseBoardConfig(data []byte) (BoardConfig, error) {
+	var cfg BoardConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return BoardConfig{}, fmt.Errorf("parse board config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return BoardConfig{}, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the configuration defines at least one column, that
+// column IDs are unique and that no status is claimed by two columns.
+func (c BoardConfig) Validate() error {
+	if len(c.Columns) == 0 {
+		return fmt.Errorf("board config: no columns defined")
+	}
+
+	ids := make(map[string]bool)
+	claimed := make(map[string]string)
+	for i, col := range c.Columns {
+		if col.ID == "" {
+			return fmt.Errorf("board config: column %d has no id", i)
+		}
+		if ids[col.ID] {
+			return fmt.Errorf("board config: duplicate column id %q", col.ID)
+		}
+		ids[col.ID] = true
+
+		if len(col.Statuses) == 0 {
+			return fmt.Errorf("board config: column %q maps no statuses", col.ID)
+		}
+		if col.WIPLimit < 0 {
+			return fmt.Errorf("board config: column %q has a negative wip_limit", col.ID)
+		}
+		for _, s := range col.Statuses {
+			key := strings.ToLower(s)
+			if other, ok := claimed[key]; ok {
+				return fmt.Errorf("board config: status %q is mapped by both %q and %q", s, other, col.ID)
+			}
+			claimed[key] = col.ID
+		}
+	}
+	return nil
+}
+
+// columnFor returns the index of the column an issue belongs in, or -1.
+// A column naming the issue's raw bd status takes precedence over one
+// naming its normalized status.
+func (c BoardConfig) columnFor(issue Issue) int {
+	if issue.RawStatus != "" {
+		for i, col := range c.Columns {
+			if containsFold(col.Statuses, issue.RawStatus) {
+				return i
+			}
+		}
+	}
+	for i, col := range c.Columns {
+		if containsFold(col.Statuses, string(issue.Status)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsFold(values []string, v string) bool {
+	for _, x := range values {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// columns returns empty board columns for the configuration.
+func (c BoardConfig) columns() []Column {
+	columns := make([]Column, len(c.Columns))
+	for i, cc := range c.Columns {
+		columns[i] = Column{
+			ID:       cc.ID,
+			Label:    cc.Label,
+			Statuses: cc.Statuses,
+			WIPLimit: cc.WIPLimit,
+			Issues:   []IssueSummary{},
+		}
+		// Columns keyed by a normalized status keep it for status colouring
+		for _, s := range cc.Statuses {
+			if st := Status(strings.ToLower(s)); st.Valid() {
+				columns[i].Status = st
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// GroupBy names the issue attribute swimlanes are grouped by.
+type GroupBy string
+
+const (
+	GroupByNone     GroupBy = ""
+	GroupByPriority GroupBy = "priority"
+	GroupByType     GroupBy = "type"
+	GroupByAssignee GroupBy = "assignee"
+	GroupByRig      GroupBy = "rig"
+	GroupByParent   GroupBy = "parent"
+)
+
+// ParseGroupBy validates a swimlane grouping.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(strings.ToLower(s)); g {
+	case GroupByNone, GroupByPriority, GroupByType, GroupByAssignee, GroupByRig, GroupByParent:
+		return g, nil
+	}
+	return "", fmt.Errorf("invalid group_by %q: use priority, type, assignee, rig or parent", s)
+}
+
+// laneKey returns the swimlane key and label of an issue.
+func (g GroupBy) laneKey(issue Issue) (key, label string) {
+	switch g {
+	case GroupByPriority:
+		return string(issue.Priority), string(issue.Priority)
+	case GroupByType:
+		return issue.IssueType, issue.IssueType
+	case GroupByAssignee:
+		return issue.Assignee, issue.Assignee
+	case GroupByRig:
+		rig := IssueRig(issue.ID)
+		return rig, rig
+	case GroupByParent:
+		if issue.Parent != nil {
+			return issue.Parent.ID, issue.Parent.Title
+		}
+	}
+	return "", ""
+}
+
+// IssueRig returns the rig prefix of a bead ID ("gt" for "gt-abc12").
+func IssueRig(id string) string {
+	if i := strings.LastIndex(id, "-"); i > 0 {
+		return id[:i]
+	}
+	return ""
+}
+
+// NewBoard creates an empty board with standard columns.
+func NewBoard() Board {
+	return NewBoardWithConfig(DefaultBoardConfig())
+}
+
+// NewBoardWithConfig creates an empty board with the configured columns.
+func NewBoardWithConfig(cfg BoardConfig) Board {
+	return Board{Columns: cfg.columns()}
+}
+
+// AddIssue adds an issue summary to the column for its status. Issues
+// whose status has no column are collected in Unmapped.
 func (b *Board) AddIssue(issue IssueSummary) {
 	for i := range b.Columns {
 		if b.Columns[i].Status == issue.Status {
-			b.Columns[i].Issues = append(b.Columns[i].Issues, issue)
-			b.Columns[i].Count++
+			b.Columns[i].add(issue)
 			b.Total++
 			return
 		}
 	}
+	b.Unmapped = append(b.Unmapped, issue)
+}
+
+func (c *Column) add(issue IssueSummary) {
+	c.Issues = append(c.Issues, issue)
+	c.Count++
+	c.OverLimit = c.WIPLimit > 0 && c.Count > c.WIPLimit
+}
+
+// BuildBoard lays issues out in the configured columns, optionally split
+// into swimlanes. WIP limits apply to whole columns across all swimlanes.
+// Issues whose status no column maps are reported in Unmapped rather than
+// dropped.
+func BuildBoard(cfg BoardConfig, issues []Issue, groupBy GroupBy) Board {
+	board := NewBoardWithConfig(cfg)
+	board.GroupBy = groupBy
+
+	lanes := make(map[string]int)
+	for _, issue := range issues {
+		summary := issue.Summary()
+		col := cfg.columnFor(issue)
+		if col < 0 {
+			board.Unmapped = append(board.Unmapped, summary)
+			continue
+		}
+		board.Columns[col].add(summary)
+		board.Total++
+
+		if groupBy == GroupByNone {
+			continue
+		}
+		key, label := groupBy.laneKey(issue)
+		lane, ok := lanes[key]
+		if !ok {
+			lane = len(board.Swimlanes)
+			lanes[key] = lane
+			if label == "" {
+				label = "None"
+			}
+			board.Swimlanes = append(board.Swimlanes, Swimlane{Key: key, Label: label, Columns: cfg.columns()})
+		}
+		board.Swimlanes[lane].Columns[col].Issues = append(board.Swimlanes[lane].Columns[col].Issues, summary)
+		board.Swimlanes[lane].Columns[col].Count++
+		board.Swimlanes[lane].Count++
+	}
+
+	sortSwimlanes(board.Swimlanes, groupBy)
+	return board
+}
+
+// sortSwimlanes orders lanes by priority level or alphabetically, with the
+// lane of issues lacking the attribute last.
+func sortSwimlanes(lanes []Swimlane, groupBy GroupBy) {
+	sort.SliceStable(lanes, func(i, j int) bool {
+		a, b := lanes[i], lanes[j]
+		if (a.Key == "") != (b.Key == "") {
+			return b.Key == ""
+		}
+		if groupBy == GroupByPriority {
+			return Priority(a.Key).Level() < Priority(b.Key).Level()
+		}
+		return a.Label < b.Label
+	})
 }
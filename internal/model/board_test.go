@@ -0,0 +1,62 @@
+package model
+
+import "testing"
+
+func TestBuildBoardCustomColumns(t *testing.T) {
+	cfg := BoardConfig{Columns: []ColumnConfig{
+		{ID: "todo", Label: "To Do", Statuses: []string{"open"}},
+		{ID: "parked", Label: "Parked", Statuses: []string{"deferred", "blocked"}},
+		{ID: "doing", Label: "Doing", Statuses: []string{"in_progress"}, WIPLimit: 1},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	issues := []Issue{
+		{ID: "gt-1", Status: StatusPending, RawStatus: "open", Priority: PriorityLow},
+		{ID: "gt-2", Status: StatusPending, RawStatus: "deferred", Priority: PriorityCritical},
+		{ID: "bd-3", Status: StatusInProgress, RawStatus: "in_progress", Priority: PriorityLow},
+		{ID: "bd-4", Status: StatusInProgress, RawStatus: "in_progress", Priority: PriorityHigh},
+		{ID: "bd-5", Status: StatusDone, RawStatus: "closed"},
+	}
+
+	board := BuildBoard(cfg, issues, GroupByPriority)
+
+	if board.Columns[0].Count != 1 || board.Columns[1].Count != 1 {
+		t.Errorf("expected the raw deferred status to win over pending, got %d/%d", board.Columns[0].Count, board.Columns[1].Count)
+	}
+	if !board.Columns[2].OverLimit {
+		t.Error("expected the doing column to be over its WIP limit")
+	}
+	if board.Total != 4 || len(board.Unmapped) != 1 || board.Unmapped[0].ID != "bd-5" {
+		t.Errorf("expected the closed issue to be unmapped, got total %d unmapped %+v", board.Total, board.Unmapped)
+	}
+
+	if len(board.Swimlanes) != 3 {
+		t.Fatalf("expected 3 priority lanes, got %d", len(board.Swimlanes))
+	}
+	if board.Swimlanes[0].Key != string(PriorityCritical) || board.Swimlanes[2].Key != string(PriorityLow) {
+		t.Errorf("expected lanes ordered by priority, got %s..%s", board.Swimlanes[0].Key, board.Swimlanes[2].Key)
+	}
+	if board.Swimlanes[2].Count != 2 {
+		t.Errorf("expected 2 issues in the low lane, got %d", board.Swimlanes[2].Count)
+	}
+}
+
+func TestBoardConfigValidate(t *testing.T) {
+	cfg := BoardConfig{Columns: []ColumnConfig{
+		{ID: "a", Statuses: []string{"open"}},
+		{ID: "b", Statuses: []string{"OPEN"}},
+	}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error for a status mapped by two columns")
+	}
+}
+
+func TestIssueRig(t *testing.T) {
+	for id, want := range map[string]string{"gt-abc12": "gt", "gvi-viewer-x1": "gvi-viewer", "nohyphen": ""} {
+		if got := IssueRig(id); got != want {
+			t.Errorf("IssueRig(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
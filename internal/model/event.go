@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // EventType represents the type of SSE event.
 type EventType string
@@ -85,3 +88,76 @@ func NewIssueUpdatedEvent(id string, status, previousStatus Status) Event {
 		Timestamp: now,
 	}
 }
+
+// NewIssueDeletedEvent creates an issue_deleted event.
+func NewIssueDeletedEvent(id string) Event {
+	now := time.Now()
+	return Event{
+		Type: EventTypeIssueDeleted,
+		Data: IssueDeletedEvent{
+			ID:        id,
+			DeletedAt: now,
+		},
+		Timestamp: now,
+	}
+}
+
+// IssueState is the part of an issue whose change is reported as an
+// issue_updated event.
+type IssueState struct {
+	Status    Status
+	RawStatus string
+	Title     string
+	Priority  Priority
+	UpdatedAt time.Time
+}
+
+// IssueStates returns the reportable state of each issue, keyed by ID.
+func IssueStates(issues []Issue) map[string]IssueState {
+	states := make(map[string]IssueState, len(issues))
+	for _, issue := range issues {
+		states[issue.ID] = IssueState{
+			Status:    issue.Status,
+			RawStatus: issue.RawStatus,
+			Title:     issue.Title,
+			Priority:  issue.Priority,
+			UpdatedAt: issue.UpdatedAt,
+		}
+	}
+	return states
+}
+
+// IssueChange is an issue created, updated or deleted between two
+// observations. Before is zero for a created issue, After for a deleted one.
+type IssueChange struct {
+	Type   EventType
+	ID     string
+	Before IssueState
+	After  IssueState
+}
+
+// DiffIssueStates returns the changes from previous to current: created and
+// updated issues in ID order, then deleted ones in ID order.
+func DiffIssueStates(previous, current map[string]IssueState) []IssueChange {
+	var changes, deleted []IssueChange
+	for id, after := range current {
+		before, ok := previous[id]
+		switch {
+		case !ok:
+			changes = append(changes, IssueChange{Type: EventTypeIssueCreated, ID: id, After: after})
+		case before != after:
+			changes = append(changes, IssueChange{Type: EventTypeIssueUpdated, ID: id, Before: before, After: after})
+		}
+	}
+	for id, before := range previous {
+		if _, ok := current[id]; !ok {
+			deleted = append(deleted, IssueChange{Type: EventTypeIssueDeleted, ID: id, Before: before})
+		}
+	}
+	byID := func(c []IssueChange) func(i, j int) bool {
+		return func(i, j int) bool { return c[i].ID < c[j].ID }
+	}
+	sort.Slice(changes, byID(changes))
+	sort.Slice(deleted, byID(deleted))
+	return append(changes, deleted...)
+}
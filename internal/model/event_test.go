@@ -0,0 +1,43 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffIssueStates(t *testing.T) {
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	previous := IssueStates([]Issue{
+		{ID: "gt-1", Status: StatusPending, Title: "A"},
+		{ID: "gt-2", Status: StatusPending, Title: "B"},
+		{ID: "gt-3", Status: StatusPending, Title: "C"},
+		{ID: "gt-5", Status: StatusPending, Title: "E", Priority: PriorityLow},
+		{ID: "gt-6", Status: StatusPending, Title: "F"},
+		{ID: "gt-7", Status: StatusPending, Title: "G"},
+	})
+	current := IssueStates([]Issue{
+		{ID: "gt-1", Status: StatusPending, Title: "A"},
+		{ID: "gt-2", Status: StatusDone, Title: "B"},
+		{ID: "gt-4", Status: StatusPending, Title: "D"},
+		{ID: "gt-5", Status: StatusPending, Title: "E", Priority: PriorityHigh},
+		{ID: "gt-6", Status: StatusPending, Title: "F, renamed"},
+		{ID: "gt-7", Status: StatusPending, Title: "G", UpdatedAt: now},
+	})
+
+	var got []string
+	for _, c := range DiffIssueStates(previous, current) {
+		got = append(got, string(c.Type)+" "+c.ID)
+	}
+	want := []string{
+		"issue_updated gt-2",
+		"issue_created gt-4",
+		"issue_updated gt-5",
+		"issue_updated gt-6",
+		"issue_updated gt-7",
+		"issue_deleted gt-3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+}
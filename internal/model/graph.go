@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // EdgeType represents the type of dependency relationship.
@@ -36,10 +37,15 @@ const (
 
 // GraphNode represents a node in the dependency graph.
 type GraphNode struct {
-	ID       string   `json:"id"`
-	Title    string   `json:"title"`
-	Status   Status   `json:"status"`
-	Priority Priority `json:"priority"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    Status     `json:"status"`
+	RawStatus string     `json:"raw_status,omitempty"`
+	Priority  Priority   `json:"priority"`
+	IssueType string     `json:"issue_type,omitempty"`
+	Assignee  string     `json:"assignee,omitempty"`
+	Labels    []string   `json:"labels,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
 }
 
 // GraphEdge represents a directed edge in the dependency graph.
@@ -83,6 +89,22 @@ func (g *Graph) AddEdge(edge GraphEdge) {
 	g.Stats.EdgeCount++
 }
 
+// SubGraph returns the part of g induced by the given node IDs.
+func (g *Graph) SubGraph(keep map[string]bool) Graph {
+	sub := NewGraph()
+	for _, n := range g.Nodes {
+		if keep[n.ID] {
+			sub.AddNode(n)
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.From] && keep[e.To] {
+			sub.AddEdge(e)
+		}
+	}
+	return sub
+}
+
 // GraphFormat specifies output format for the graph endpoint.
 type GraphFormat string
 
@@ -178,3 +200,43 @@ func (g *Graph) ToDOT() string {
 	b.WriteString("}\n")
 	return b.String()
 }
+
+// ToMermaid exports the graph as a Mermaid flowchart, which renders inline
+// in GitHub and GitLab Markdown. Issue IDs are replaced by generated node
+// names, since Mermaid reads hyphens as edge syntax.
+func (g *Graph) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	names := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		name := fmt.Sprintf("n%d", i)
+		names[node.ID] = name
+		label := strings.ReplaceAll(node.ID+": "+node.Title, "\"", "#quot;")
+		class := string(node.Status)
+		if class == "" {
+			class = string(StatusUnknown)
+		}
+		b.WriteString(fmt.Sprintf("  %s[\"%s\"]:::%s\n", name, label, class))
+	}
+
+	for _, edge := range g.Edges {
+		from, to := names[edge.From], names[edge.To]
+		if from == "" || to == "" {
+			continue
+		}
+		arrow := "-.->"
+		if edge.Type == EdgeTypeBlocks || edge.Type == EdgeTypeBlockedBy {
+			arrow = "-->"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s|%s| %s\n", from, arrow, edge.Type, to))
+	}
+
+	// Same colors as ToDOT
+	b.WriteString("  classDef pending fill:#3b82f6,color:#fff\n")
+	b.WriteString("  classDef in_progress fill:#eab308,color:#000\n")
+	b.WriteString("  classDef done fill:#22c55e,color:#000\n")
+	b.WriteString("  classDef blocked fill:#ef4444,color:#fff\n")
+	b.WriteString("  classDef unknown fill:#6b7280,color:#fff\n")
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphToMermaid(t *testing.T) {
+	g := NewGraph()
+	g.AddNode(GraphNode{ID: "gt-1", Title: `Fix "login"`, Status: StatusInProgress})
+	g.AddNode(GraphNode{ID: "gt-2", Title: "Add export", Status: StatusPending})
+	g.AddEdge(GraphEdge{From: "gt-1", To: "gt-2", Type: EdgeTypeBlocks})
+	g.AddEdge(GraphEdge{From: "gt-2", To: "gt-9", Type: EdgeTypeRelates})
+
+	out := g.ToMermaid()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`  n0["gt-1: Fix #quot;login#quot;"]:::in_progress`,
+		`  n1["gt-2: Add export"]:::pending`,
+		"  n0 -->|blocks| n1\n",
+		"classDef in_progress",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "gt-9") {
+		t.Errorf("edge to a missing node was written:\n%s", out)
+	}
+}
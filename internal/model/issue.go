@@ -1,7 +1,10 @@
 // Package model defines the core domain types for Gastown Viewer Intent.
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Status represents the state of an issue.
 type Status string
@@ -11,23 +14,127 @@ const (
 	StatusInProgress Status = "in_progress"
 	StatusDone       Status = "done"
 	StatusBlocked    Status = "blocked"
+
+	// StatusUnknown is a status bd reported that has no mapping.
+	StatusUnknown Status = "unknown"
 )
 
-// Priority represents issue priority level.
+// Valid reports whether s is one of the normalized statuses. StatusUnknown
+// is not a valid mapping target.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusInProgress, StatusDone, StatusBlocked:
+		return true
+	}
+	return false
+}
+
+// Priority represents issue priority level. The levels correspond to bd's
+// P0 (critical) through P4 (backlog) scale.
 type Priority string
 
 const (
-	PriorityHigh   Priority = "high"
-	PriorityMedium Priority = "medium"
-	PriorityLow    Priority = "low"
+	PriorityCritical Priority = "critical"
+	PriorityHigh     Priority = "high"
+	PriorityMedium   Priority = "medium"
+	PriorityLow      Priority = "low"
+	PriorityBacklog  Priority = "backlog"
 )
 
+// Priorities lists all priority levels from most to least urgent.
+var Priorities = []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow, PriorityBacklog}
+
+// Level returns the priority's position on the P0-P4 scale, or -1 for an
+// unknown priority.
+func (p Priority) Level() int {
+	for i, level := range Priorities {
+		if p == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParsePriority parses a priority name or a P0-P4 level.
+func ParsePriority(s string) (Priority, bool) {
+	s = strings.ToLower(s)
+	if len(s) == 2 && s[0] == 'p' && s[1] >= '0' && s[1] <= '4' {
+		return Priorities[s[1]-'0'], true
+	}
+	if p := Priority(s); p.Level() >= 0 {
+		return p, true
+	}
+	return "", false
+}
+
+// Criterion is one acceptance criterion from an issue's "Done when" section.
+type Criterion struct {
+	Text     string `json:"text"`
+	Section  string `json:"section,omitempty"`
+	Checkbox bool   `json:"checkbox"` // written as a Markdown checkbox
+	Checked  bool   `json:"checked"`
+}
+
+// CriteriaProgress counts checked acceptance criteria. Only checkbox items
+// are tracked, since plain list items carry no completion state.
+type CriteriaProgress struct {
+	Total   int     `json:"total"`
+	Checked int     `json:"checked"`
+	Percent float64 `json:"percent"`
+}
+
+// NewCriteriaProgress summarises criteria, returning nil when none of them
+// is a checkbox.
+func NewCriteriaProgress(criteria []Criterion) *CriteriaProgress {
+	var p CriteriaProgress
+	for _, c := range criteria {
+		if !c.Checkbox {
+			continue
+		}
+		p.Total++
+		if c.Checked {
+			p.Checked++
+		}
+	}
+	if p.Total == 0 {
+		return nil
+	}
+	p.Percent = 100 * float64(p.Checked) / float64(p.Total)
+	return &p
+}
+
+// Add accumulates other into p.
+func (p *CriteriaProgress) Add(other *CriteriaProgress) {
+	if other == nil {
+		return
+	}
+	p.Total += other.Total
+	p.Checked += other.Checked
+	p.Percent = 100 * float64(p.Checked) / float64(p.Total)
+}
+
+// Unmet reports whether any tracked criterion is unchecked.
+func (p *CriteriaProgress) Unmet() bool {
+	return p != nil && p.Checked < p.Total
+}
+
 // IssueSummary is a compact representation for lists and references.
 type IssueSummary struct {
-	ID       string   `json:"id"`
-	Title    string   `json:"title"`
-	Status   Status   `json:"status"`
-	Priority Priority `json:"priority"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    Status     `json:"status"`
+	RawStatus string     `json:"raw_status,omitempty"`
+	Priority  Priority   `json:"priority"`
+	IssueType string     `json:"issue_type,omitempty"`
+	Assignee  string     `json:"assignee,omitempty"`
+	Labels    []string   `json:"labels,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+
+	Criteria *CriteriaProgress `json:"criteria,omitempty"`
+
+	// Agent is the Gas Town agent working on the issue, if any.
+	Agent *AgentRef `json:"agent,omitempty"`
 }
 
 // Issue is the full representation of a Beads issue.
@@ -36,7 +143,11 @@ type Issue struct {
 	Title       string         `json:"title"`
 	Description string         `json:"description,omitempty"`
 	Status      Status         `json:"status"`
+	RawStatus   string         `json:"raw_status,omitempty"` // status as reported by bd
 	Priority    Priority       `json:"priority"`
+	IssueType   string         `json:"issue_type,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
 	Parent      *IssueSummary  `json:"parent,omitempty"`
 	Children    []IssueSummary `json:"children"`
 	Blocks      []IssueSummary `json:"blocks"`
@@ -44,6 +155,40 @@ type Issue struct {
 	DoneWhen    []string       `json:"done_when,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	ClosedAt    *time.Time     `json:"closed_at,omitempty"`
+
+	Criteria         []Criterion       `json:"criteria,omitempty"`
+	CriteriaProgress *CriteriaProgress `json:"criteria_progress,omitempty"`
+
+	// DescriptionHTML is the description rendered as sanitized HTML, set
+	// only when requested with ?render=html.
+	DescriptionHTML string `json:"description_html,omitempty"`
+
+	// Agents and Convoys link the issue to the Gas Town agents working on
+	// it and the convoys containing it.
+	Agents  []AgentRef  `json:"agents,omitempty"`
+	Convoys []ConvoyRef `json:"convoys,omitempty"`
+}
+
+// Summary returns the compact representation of the issue.
+func (i Issue) Summary() IssueSummary {
+	var updated *time.Time
+	if !i.UpdatedAt.IsZero() {
+		updated = &i.UpdatedAt
+	}
+	return IssueSummary{
+		ID:        i.ID,
+		Title:     i.Title,
+		Status:    i.Status,
+		RawStatus: i.RawStatus,
+		Priority:  i.Priority,
+		IssueType: i.IssueType,
+		Assignee:  i.Assignee,
+		Labels:    i.Labels,
+		UpdatedAt: updated,
+		ClosedAt:  i.ClosedAt,
+		Criteria:  i.CriteriaProgress,
+	}
 }
 
 // IssueListResponse is the response for GET /api/v1/issues.
@@ -56,11 +201,16 @@ type IssueListResponse struct {
 
 // IssueFilter defines query parameters for listing issues.
 type IssueFilter struct {
-	Status string
-	Parent string
-	Search string
-	Limit  int
-	Offset int
+	Status   string
+	Parent   string
+	Search   string
+	Priority []Priority
+	Type     []string
+	Limit    int
+	Offset   int
+
+	// UnmetCriteria keeps only closed issues with unchecked acceptance criteria.
+	UnmetCriteria bool
 }
 
 // NewIssueFilter returns a filter with default values.
@@ -70,3 +220,40 @@ func NewIssueFilter() IssueFilter {
 		Offset: 0,
 	}
 }
+
+// Match reports whether an issue satisfies the filter's parent, search,
+// priority, type and criteria constraints. Status is applied by the adapter
+// when querying bd. Search matches the ID, title or description,
+// ignoring case.
+func (f IssueFilter) Match(issue Issue) bool {
+	if f.Parent != "" && (issue.Parent == nil || issue.Parent.ID != f.Parent) {
+		return false
+	}
+	if f.Search != "" && !hasSubstringFold(issue.ID, f.Search) && !hasSubstringFold(issue.Title, f.Search) &&
+		!hasSubstringFold(issue.Description, f.Search) {
+		return false
+	}
+	if f.UnmetCriteria && (issue.Status != StatusDone || !issue.CriteriaProgress.Unmet()) {
+		return false
+	}
+	if len(f.Priority) > 0 && !contains(f.Priority, issue.Priority) {
+		return false
+	}
+	if len(f.Type) > 0 && !contains(f.Type, issue.IssueType) {
+		return false
+	}
+	return true
+}
+
+func hasSubstringFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// TimelineSource identifies where a timeline entry came from.
+type TimelineSource string
+
+const (
+	TimelineSourceBD    TimelineSource = "bd"    // bd's event log
+	TimelineSourceGVID  TimelineSource = "gvid"  // gvid's recorded observations
+	TimelineSourceIssue TimelineSource = "issue" // derived from issue timestamps
+)
+
+// TimelineEntry is a single status change of an issue.
+type TimelineEntry struct {
+	Time   time.Time      `json:"time"`
+	From   Status         `json:"from,omitempty"`
+	To     Status         `json:"to"`
+	Actor  string         `json:"actor,omitempty"`
+	Source TimelineSource `json:"source"`
+}
+
+// Timeline is the status history of an issue with derived flow times.
+// Lead time runs from creation to close; cycle time from the first move to
+// in_progress to close; wait time from creation to the first move to
+// in_progress (or to now, while the issue has not been picked up).
+type Timeline struct {
+	IssueID          string             `json:"issue_id"`
+	Source           TimelineSource     `json:"source"`
+	Entries          []TimelineEntry    `json:"entries"`
+	CreatedAt        time.Time          `json:"created_at"`
+	StartedAt        *time.Time         `json:"started_at,omitempty"`
+	ClosedAt         *time.Time         `json:"closed_at,omitempty"`
+	LeadTimeSeconds  *float64           `json:"lead_time_seconds,omitempty"`
+	CycleTimeSeconds *float64           `json:"cycle_time_seconds,omitempty"`
+	WaitTimeSeconds  float64            `json:"wait_time_seconds"`
+	TimeInStatus     map[Status]float64 `json:"time_in_status_seconds"`
+}
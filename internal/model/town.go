@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// AgentRef is a Gas Town agent working on an issue.
+type AgentRef struct {
+	Address    string    `json:"address"`
+	Name       string    `json:"name"`
+	Role       string    `json:"role"`
+	Rig        string    `json:"rig,omitempty"`
+	Status     string    `json:"status"`
+	Via        string    `json:"via"` // "hook" or "molecule"
+	Molecule   string    `json:"molecule,omitempty"`
+	LastActive time.Time `json:"last_active,omitempty"`
+}
+
+// Ways an agent can be linked to an issue.
+const (
+	AgentViaHook     = "hook"     // the issue is on the agent's hook
+	AgentViaMolecule = "molecule" // the agent's molecule works on the issue
+)
+
+// ConvoyRef is a Gas Town convoy that contains an issue.
+type ConvoyRef struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Progress int    `json:"progress"`
+	Total    int    `json:"total"`
+}
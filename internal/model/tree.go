@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Rollup aggregates the progress of all descendants of an issue.
+type Rollup struct {
+	Total               int                 `json:"total"`
+	Done                int                 `json:"done"`
+	InProgress          int                 `json:"in_progress"`
+	Blocked             int                 `json:"blocked"`
+	Progress            float64             `json:"progress"` // Done / Total, 0 without descendants
+	HighestOpenPriority Priority            `json:"highest_open_priority,omitempty"`
+	Criteria            *CriteriaProgress   `json:"criteria,omitempty"` // acceptance criteria across descendants
+	Estimate            *CompletionEstimate `json:"estimate,omitempty"`
+}
+
+// CompletionEstimate projects when the remaining descendants will be done
+// if they keep closing at the recent rate.
+type CompletionEstimate struct {
+	Remaining    int       `json:"remaining"`
+	ClosedPerDay float64   `json:"closed_per_day"`
+	WindowDays   int       `json:"window_days"`
+	CompletesAt  time.Time `json:"completes_at"`
+}
+
+// IssueTree is an issue with its descendants and their rolled-up progress.
+type IssueTree struct {
+	IssueSummary
+	Rollup   Rollup      `json:"rollup"`
+	Children []IssueTree `json:"children"`
+}
+
+// EpicListResponse is the response for GET /api/v1/epics.
+type EpicListResponse struct {
+	Epics []IssueTree `json:"epics"`
+	Total int         `json:"total"`
+}
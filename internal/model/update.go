@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ValidateIssueID rejects issue IDs that bd could mistake for a flag or
+// split into several arguments.
+func ValidateIssueID(id string) error {
+	switch {
+	case id == "":
+		return errors.New("issue ID is required")
+	case strings.HasPrefix(id, "-"):
+		return fmt.Errorf("invalid issue ID %q: must not start with '-'", id)
+	case strings.IndexFunc(id, unicode.IsSpace) >= 0:
+		return fmt.Errorf("invalid issue ID %q: must not contain whitespace", id)
+	}
+	return nil
+}
+
+// IssueUpdate is a partial update of an issue. Nil fields are left
+// unchanged.
+type IssueUpdate struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// Status is a normalized status or a raw bd status.
+	Status   *string   `json:"status,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+}
+
+// Empty reports whether the update changes nothing.
+func (u IssueUpdate) Empty() bool {
+	return u.Title == nil && u.Description == nil && u.Status == nil && u.Priority == nil
+}
+
+// Validate checks that the update changes something and that its fields
+// are well formed.
+func (u IssueUpdate) Validate() error {
+	if u.Empty() {
+		return errors.New("update must set at least one of title, description, status or priority")
+	}
+	if u.Title != nil && strings.TrimSpace(*u.Title) == "" {
+		return errors.New("title must not be empty")
+	}
+	if u.Status != nil && strings.TrimSpace(*u.Status) == "" {
+		return errors.New("status must not be empty")
+	}
+	if u.Priority != nil && u.Priority.Level() < 0 {
+		return fmt.Errorf("invalid priority %q", *u.Priority)
+	}
+	return nil
+}
+
+// Dependency is a dependency between two issues: Issue depends on
+// DependsOn.
+type Dependency struct {
+	Issue     string   `json:"issue,omitempty"`
+	DependsOn string   `json:"depends_on"`
+	Type      EdgeType `json:"type,omitempty"`
+}
+
+// Validate checks the dependency, defaulting its type to blocks.
+func (d *Dependency) Validate() error {
+	if d.Issue == "" || d.DependsOn == "" {
+		return errors.New("issue and depends_on are required")
+	}
+	for _, id := range []string{d.Issue, d.DependsOn} {
+		if err := ValidateIssueID(id); err != nil {
+			return err
+		}
+	}
+	if d.Issue == d.DependsOn {
+		return errors.New("an issue cannot depend on itself")
+	}
+	if d.Type == "" {
+		d.Type = EdgeTypeBlocks
+	}
+	if ParseEdgeType(string(d.Type)) == EdgeTypeUnknown {
+		return fmt.Errorf("invalid dependency type %q", d.Type)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SavedView is a named filter, sort and grouping that can be applied to the
+// issue list, board and graph with ?view=name.
+type SavedView struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	Status        string     `json:"status,omitempty"`
+	Parent        string     `json:"parent,omitempty"`
+	Search        string     `json:"search,omitempty"`
+	Priority      []Priority `json:"priority,omitempty"`
+	Type          []string   `json:"type,omitempty"`
+	UnmetCriteria bool       `json:"unmet_criteria,omitempty"`
+	// Query is an issue query in the language of package query, applied
+	// on top of the other filters.
+	Query string `json:"query,omitempty"`
+
+	// Sort orders issues by a field, optionally prefixed with "-" for
+	// descending order. See SortIssues.
+	Sort    string  `json:"sort,omitempty"`
+	GroupBy GroupBy `json:"group_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+var viewNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// Validate checks the view's name, priorities, sort and grouping.
+func (v SavedView) Validate() error {
+	if !viewNamePattern.MatchString(v.Name) {
+		return fmt.Errorf("invalid view name %q: use up to 64 letters, digits, '.', '_' or '-'", v.Name)
+	}
+	for _, p := range v.Priority {
+		if p.Level() < 0 {
+			return fmt.Errorf("invalid priority %q", p)
+		}
+	}
+	if err := ValidateSort(v.Sort); err != nil {
+		return err
+	}
+	if _, err := ParseGroupBy(string(v.GroupBy)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Filter returns the issue filter the view describes.
+func (v SavedView) Filter() IssueFilter {
+	f := NewIssueFilter()
+	f.Status = v.Status
+	f.Parent = v.Parent
+	f.Search = v.Search
+	f.Priority = v.Priority
+	f.Type = v.Type
+	f.UnmetCriteria = v.UnmetCriteria
+	return f
+}
+
+// sortKeys compares two issues by one field.
+var sortKeys = map[string]func(a, b Issue) int{
+	"id":    func(a, b Issue) int { return strings.Compare(a.ID, b.ID) },
+	"title": func(a, b Issue) int { return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) },
+	"priority": func(a, b Issue) int {
+		return a.Priority.Level() - b.Priority.Level()
+	},
+	"status":  func(a, b Issue) int { return strings.Compare(string(a.Status), string(b.Status)) },
+	"created": func(a, b Issue) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updated": func(a, b Issue) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+}
+
+// ValidateSort checks a sort specification.
+func ValidateSort(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	if _, ok := sortKeys[strings.TrimPrefix(spec, "-")]; !ok {
+		return fmt.Errorf("invalid sort %q: use id, title, priority, status, created or updated, optionally prefixed with -", spec)
+	}
+	return nil
+}
+
+// SortIssues orders issues by spec, a field name optionally prefixed with
+// "-" for descending order. Ties keep their existing order. An empty or
+// unknown spec leaves issues unchanged.
+func SortIssues(issues []Issue, spec string) {
+	desc := strings.HasPrefix(spec, "-")
+	cmp, ok := sortKeys[strings.TrimPrefix(spec, "-")]
+	if !ok {
+		return
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		c := cmp(issues[i], issues[j])
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
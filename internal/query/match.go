@@ -0,0 +1,240 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Match reports whether issue satisfies the query. Relative times such as
+// updated:<7d are measured back from now.
+func (q *Query) Match(issue model.Issue, now time.Time) bool {
+	if q.Empty() {
+		return true
+	}
+	return q.root.match(issue, now)
+}
+
+// Filter returns the issues that satisfy the query.
+func (q *Query) Filter(issues []model.Issue, now time.Time) []model.Issue {
+	if q.Empty() {
+		return issues
+	}
+	result := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if q.root.match(issue, now) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+type node interface {
+	match(issue model.Issue, now time.Time) bool
+}
+
+type andNode []node
+
+func (n andNode) match(issue model.Issue, now time.Time) bool {
+	for _, c := range n {
+		if !c.match(issue, now) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []node
+
+func (n orNode) match(issue model.Issue, now time.Time) bool {
+	for _, c := range n {
+		if c.match(issue, now) {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct{ n node }
+
+func (n notNode) match(issue model.Issue, now time.Time) bool {
+	return !n.n.match(issue, now)
+}
+
+// textNode matches free text, already lowercased, against the ID, title
+// and description.
+type textNode string
+
+func (n textNode) match(issue model.Issue, now time.Time) bool {
+	s := string(n)
+	return strings.Contains(strings.ToLower(issue.ID), s) ||
+		strings.Contains(strings.ToLower(issue.Title), s) ||
+		strings.Contains(strings.ToLower(issue.Description), s)
+}
+
+type funcNode func(issue model.Issue, now time.Time) bool
+
+func (f funcNode) match(issue model.Issue, now time.Time) bool {
+	return f(issue, now)
+}
+
+// field describes how a field:value term is matched. Fields with compare
+// accept comparison operators; the others only match by equality.
+type field struct {
+	equal   func(value string) node
+	compare func(op operator, value string) (node, error)
+}
+
+var fields = map[string]field{
+	"id": {equal: stringField(func(i model.Issue) string { return i.ID })},
+	"status": {equal: func(v string) node {
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			return strings.EqualFold(string(i.Status), v) || strings.EqualFold(i.RawStatus, v)
+		})
+	}},
+	"type": {equal: stringField(func(i model.Issue) string { return i.IssueType })},
+	"assignee": {equal: func(v string) node {
+		if strings.EqualFold(v, "none") {
+			return funcNode(func(i model.Issue, _ time.Time) bool { return i.Assignee == "" })
+		}
+		return stringField(func(i model.Issue) string { return i.Assignee })(v)
+	}},
+	"label": {equal: func(v string) node {
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			for _, l := range i.Labels {
+				if strings.EqualFold(l, v) {
+					return true
+				}
+			}
+			return false
+		})
+	}},
+	"rig":    {equal: stringField(func(i model.Issue) string { return model.IssueRig(i.ID) })},
+	"parent": {equal: stringField(parentID)},
+	"title": {equal: func(v string) node {
+		v = strings.ToLower(v)
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			return strings.Contains(strings.ToLower(i.Title), v)
+		})
+	}},
+	"blocked-by": {equal: summariesField(func(i model.Issue) []model.IssueSummary { return i.BlockedBy })},
+	"blocks":     {equal: summariesField(func(i model.Issue) []model.IssueSummary { return i.Blocks })},
+	"priority":   {compare: comparePriority},
+	"created":    {compare: timeField(func(i model.Issue) *time.Time { return &i.CreatedAt })},
+	"updated":    {compare: timeField(func(i model.Issue) *time.Time { return &i.UpdatedAt })},
+	"closed":     {compare: timeField(func(i model.Issue) *time.Time { return i.ClosedAt })},
+}
+
+func parentID(i model.Issue) string {
+	if i.Parent == nil {
+		return ""
+	}
+	return i.Parent.ID
+}
+
+// stringField matches a string attribute, ignoring case.
+func stringField(get func(model.Issue) string) func(string) node {
+	return func(v string) node {
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			return strings.EqualFold(get(i), v)
+		})
+	}
+}
+
+// summariesField matches issues that reference the given ID.
+func summariesField(get func(model.Issue) []model.IssueSummary) func(string) node {
+	return func(v string) node {
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			for _, s := range get(i) {
+				if strings.EqualFold(s.ID, v) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
+
+// comparePriority compares P-levels, so priority:<=high matches critical
+// and high issues.
+func comparePriority(op operator, v string) (node, error) {
+	p, ok := model.ParsePriority(v)
+	if !ok {
+		return nil, fmt.Errorf("invalid priority %q: use critical, high, medium, low, backlog or P0-P4", v)
+	}
+	want := p.Level()
+	return funcNode(func(i model.Issue, _ time.Time) bool {
+		level := i.Priority.Level()
+		if level < 0 {
+			return false
+		}
+		switch op {
+		case opLT:
+			return level < want
+		case opLE:
+			return level <= want
+		case opGT:
+			return level > want
+		case opGE:
+			return level >= want
+		}
+		return level == want
+	}), nil
+}
+
+// timeField compares a timestamp against a duration, date or RFC 3339 time.
+// Issues without the timestamp never match.
+func timeField(get func(model.Issue) *time.Time) func(operator, string) (node, error) {
+	return func(op operator, v string) (node, error) {
+		if d, err := model.ParseDuration(v); err == nil {
+			// A duration is an age: updated:<7d means less than 7 days ago
+			return funcNode(func(i model.Issue, now time.Time) bool {
+				t := get(i)
+				if t == nil || t.IsZero() {
+					return false
+				}
+				recent := !t.Before(now.Add(-d))
+				if op == opGT || op == opGE {
+					return !recent
+				}
+				return recent
+			}), nil
+		}
+
+		start, end, err := parseInstant(v)
+		if err != nil {
+			return nil, err
+		}
+		return funcNode(func(i model.Issue, _ time.Time) bool {
+			t := get(i)
+			if t == nil || t.IsZero() {
+				return false
+			}
+			switch op {
+			case opLT:
+				return t.Before(start)
+			case opLE:
+				return t.Before(end)
+			case opGT:
+				return !t.Before(end)
+			case opGE:
+				return !t.Before(start)
+			}
+			return !t.Before(start) && t.Before(end)
+		}), nil
+	}
+}
+
+// parseInstant parses a date, covering the whole local day, or an RFC 3339
+// time, returning the half-open interval [start, end) it spans.
+func parseInstant(v string) (start, end time.Time, err error) {
+	if t, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, t.Add(time.Nanosecond), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid time %q: use a duration like 7d, YYYY-MM-DD or RFC 3339", v)
+}
@@ -0,0 +1,381 @@
+// Package query implements the issue query language used by the API's
+// ?q= parameter and the TUI search prompt.
+//
+// A query is a sequence of terms that must all match. Terms are free text,
+// which matches the ID, title or description, or field:value filters:
+//
+//	status:in_progress priority:<=high rig:gastown blocked-by:gvi-12 updated:<7d "login page"
+//
+// Terms can be negated with a leading "-" or NOT, combined with OR and
+// grouped with parentheses. A comma-separated value matches any of its
+// parts, so status:pending,blocked is status:pending OR status:blocked.
+//
+// Fields:
+//
+//	id, status, type, assignee, label, rig, parent, title
+//	blocked-by, blocks         an ID in the issue's blocked_by or blocks list
+//	priority                   a name or P0-P4; <, <=, >, >= compare P-levels
+//	created, updated, closed   a duration (7d, 2w, 36h), date or RFC 3339 time;
+//	                           with a duration, < means more recent than
+//
+// status matches the normalized or the raw bd status; assignee:none
+// matches unassigned issues.
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a query syntax error. Offset and Length locate the offending
+// text in the query, in bytes.
+type Error struct {
+	Offset  int    `json:"offset"`
+	Length  int    `json:"length"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("query error at offset %d: %s", e.Offset, e.Message)
+}
+
+// Query is a parsed query.
+type Query struct {
+	src  string
+	root node // nil for an empty query
+}
+
+// String returns the query source.
+func (q *Query) String() string {
+	return q.src
+}
+
+// Empty reports whether the query has no terms and so matches every issue.
+func (q *Query) Empty() bool {
+	return q == nil || q.root == nil
+}
+
+// Parse parses a query. Syntax errors are returned as *Error.
+func Parse(src string) (*Query, error) {
+	p := &parser{toks: lex(src)}
+	if err := p.lexError(); err != nil {
+		return nil, err
+	}
+
+	q := &Query{src: src}
+	if p.peek().kind == tokEOF {
+		return q, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorAt(t, "unexpected %s", t.describe())
+	}
+	q.root = root
+	return q, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokLParen
+	tokRParen
+	tokError
+)
+
+type token struct {
+	kind tokenKind
+	text string // word text or unquoted string
+	pos  int
+	end  int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokLParen:
+		return `"("`
+	case tokRParen:
+		return `")"`
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits src into tokens. An unterminated string becomes a tokError.
+func lex(src string) []token {
+	var toks []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokLParen, pos: i, end: i + 1})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokRParen, pos: i, end: i + 1})
+			i++
+		case c == '"':
+			text, end, ok := lexString(src, i)
+			if !ok {
+				return append(toks, token{kind: tokError, text: "unterminated string", pos: i, end: len(src)})
+			}
+			toks = append(toks, token{kind: tokString, text: text, pos: i, end: end})
+			i = end
+		default:
+			start := i
+			for i < len(src) && !strings.ContainsRune(" \t\n\r()\"", rune(src[i])) {
+				i++
+			}
+			toks = append(toks, token{kind: tokWord, text: src[start:i], pos: start, end: i})
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src), end: len(src)})
+}
+
+// lexString reads a double-quoted string starting at src[start], handling
+// \" and \\ escapes.
+func lexString(src string, start int) (string, int, bool) {
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if i+1 < len(src) {
+				i++
+				b.WriteByte(src[i])
+			}
+		case '"':
+			return b.String(), i + 1, true
+		default:
+			b.WriteByte(src[i])
+		}
+	}
+	return "", 0, false
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) lexError() error {
+	for _, t := range p.toks {
+		if t.kind == tokError {
+			return p.errorAt(t, "%s", t.text)
+		}
+	}
+	return nil
+}
+
+func (p *parser) errorAt(t token, format string, args ...any) *Error {
+	return &Error{Offset: t.pos, Length: t.end - t.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func isKeyword(t token, kw string) bool {
+	return t.kind == tokWord && t.text == kw
+}
+
+// parseOr parses terms joined by OR, which binds looser than the implicit AND.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if !isKeyword(p.peek(), "OR") {
+		return left, nil
+	}
+
+	alternatives := orNode{left}
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, right)
+	}
+	return alternatives, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	var terms andNode
+	for {
+		t := p.peek()
+		if t.kind == tokEOF || t.kind == tokRParen || isKeyword(t, "OR") {
+			break
+		}
+		if isKeyword(t, "AND") {
+			p.next()
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, n)
+	}
+
+	switch len(terms) {
+	case 0:
+		return nil, p.errorAt(p.peek(), "expected a term, found %s", p.peek().describe())
+	case 1:
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if isKeyword(t, "NOT") {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	if t.kind == tokWord && len(t.text) > 1 && t.text[0] == '-' {
+		// Strip the "-" and parse the rest of the word as a term
+		p.toks[p.i] = token{kind: tokWord, text: t.text[1:], pos: t.pos + 1, end: t.end}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	if t.kind == tokWord && t.text == "-" && p.toks[p.i+1].kind == tokLParen && p.toks[p.i+1].pos == t.end {
+		// -( ... ) negates a group
+		p.next()
+		n, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "expected \")\", found %s", closing.describe())
+		}
+		return n, nil
+
+	case tokString:
+		return textNode(strings.ToLower(t.text)), nil
+
+	case tokWord:
+		field, value, ok := strings.Cut(t.text, ":")
+		if !ok || field == "" {
+			return textNode(strings.ToLower(t.text)), nil
+		}
+		valuePos := t.pos + len(field) + 1
+		if value == "" {
+			// field:"quoted value"
+			if v := p.peek(); v.kind == tokString && v.pos == t.end {
+				p.next()
+				return p.parseField(field, token{kind: tokString, text: v.text, pos: t.pos, end: v.end}, v.text, valuePos)
+			}
+		}
+		return p.parseField(field, t, value, valuePos)
+	}
+
+	return nil, p.errorAt(t, "expected a term, found %s", t.describe())
+}
+
+// parseField builds the matcher for a field:value term. t spans the whole
+// term; value starts at valuePos.
+func (p *parser) parseField(field string, t token, value string, valuePos int) (node, error) {
+	fieldTok := token{pos: t.pos, end: t.pos + len(field)}
+	valueTok := token{pos: valuePos, end: t.end}
+
+	f, ok := fields[strings.ToLower(field)]
+	if !ok {
+		return nil, p.errorAt(fieldTok, "unknown field %q", field)
+	}
+
+	op, value := cutOperator(value)
+	if value == "" {
+		return nil, p.errorAt(valueTok, "missing value for %s", field)
+	}
+	if op != opEq && f.compare == nil {
+		return nil, p.errorAt(valueTok, "%s does not support %s", field, op)
+	}
+
+	if op != opEq {
+		m, err := f.compare(op, value)
+		if err != nil {
+			return nil, p.errorAt(valueTok, "%v", err)
+		}
+		return m, nil
+	}
+
+	var alternatives orNode
+	for _, v := range strings.Split(value, ",") {
+		if v == "" {
+			return nil, p.errorAt(valueTok, "empty value in list for %s", field)
+		}
+		var m node
+		var err error
+		if f.compare != nil {
+			m, err = f.compare(opEq, v)
+		} else {
+			m = f.equal(v)
+		}
+		if err != nil {
+			return nil, p.errorAt(valueTok, "%v", err)
+		}
+		alternatives = append(alternatives, m)
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return alternatives, nil
+}
+
+// operator is a comparison in a field:value term.
+type operator string
+
+const (
+	opEq operator = ""
+	opLT operator = "<"
+	opLE operator = "<="
+	opGT operator = ">"
+	opGE operator = ">="
+)
+
+func cutOperator(value string) (operator, string) {
+	for _, op := range []operator{opLE, opGE, opLT, opGT} {
+		if rest, ok := strings.CutPrefix(value, string(op)); ok {
+			return op, rest
+		}
+	}
+	return opEq, strings.TrimPrefix(value, "=")
+}
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestMatch(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	closed := now.Add(-48 * time.Hour)
+
+	issues := []model.Issue{
+		{
+			ID: "gastown-1", Title: "Login page crashes", Status: model.StatusInProgress, RawStatus: "in_progress",
+			Priority: model.PriorityHigh, IssueType: "bug", Assignee: "alice", Labels: []string{"ui"},
+			BlockedBy: []model.IssueSummary{{ID: "gastown-2"}},
+			UpdatedAt: now.Add(-2 * time.Hour), CreatedAt: now.Add(-30 * 24 * time.Hour),
+		},
+		{
+			ID: "gastown-2", Title: "Session store", Description: "Needed by the login page", Status: model.StatusDone,
+			RawStatus: "closed", Priority: model.PriorityCritical, IssueType: "task",
+			Blocks:    []model.IssueSummary{{ID: "gastown-1"}},
+			UpdatedAt: closed, CreatedAt: now.Add(-60 * 24 * time.Hour), ClosedAt: &closed,
+		},
+		{
+			ID: "beads-3", Title: "Docs", Status: model.StatusPending, RawStatus: "deferred",
+			Priority: model.PriorityLow, IssueType: "chore", Parent: &model.IssueSummary{ID: "beads-1"},
+			UpdatedAt: now.Add(-10 * 24 * time.Hour), CreatedAt: now.Add(-10 * 24 * time.Hour),
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"gastown-1", "gastown-2", "beads-3"}},
+		{"status:in_progress", []string{"gastown-1"}},
+		{"status:deferred", []string{"beads-3"}},
+		{"status:pending,done", []string{"gastown-2", "beads-3"}},
+		{"priority:high", []string{"gastown-1"}},
+		{"priority:<=high", []string{"gastown-1", "gastown-2"}},
+		{"priority:>P1", []string{"beads-3"}},
+		{"rig:gastown -type:task", []string{"gastown-1"}},
+		{"blocked-by:gastown-2", []string{"gastown-1"}},
+		{"blocks:GASTOWN-1", []string{"gastown-2"}},
+		{"updated:<7d", []string{"gastown-1", "gastown-2"}},
+		{"updated:>7d", []string{"beads-3"}},
+		{"created:>=2026-02-01", []string{"gastown-1", "beads-3"}},
+		{"closed:<3d", []string{"gastown-2"}},
+		{"closed:2026-03-08", []string{"gastown-2"}},
+		{`"login page"`, []string{"gastown-1", "gastown-2"}},
+		{`title:"login page"`, []string{"gastown-1"}},
+		{"assignee:none label:UI", nil},
+		{"assignee:alice OR parent:beads-1", []string{"gastown-1", "beads-3"}},
+		{"-(status:done OR type:chore)", []string{"gastown-1"}},
+		{"NOT rig:gastown", []string{"beads-3"}},
+		{"docs AND priority:low", []string{"beads-3"}},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+
+		var got []string
+		for _, issue := range q.Filter(issues, now) {
+			got = append(got, issue.ID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		offset int
+		length int
+	}{
+		{"status:done colour:red", 12, 6},
+		{"priority:urgent", 9, 6},
+		{"type:<bug", 5, 4},
+		{"updated:<soon", 8, 5},
+		{`title:"unterminated`, 6, 13},
+		{"(status:done", 12, 0},
+		{"status:done )", 12, 1},
+		{"a OR", 4, 0},
+		{"status:", 7, 0},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		var qerr *Error
+		if !errors.As(err, &qerr) {
+			t.Errorf("Parse(%q): expected *Error, got %v", tt.query, err)
+			continue
+		}
+		if qerr.Offset != tt.offset || qerr.Length != tt.length {
+			t.Errorf("Parse(%q): error at %d+%d (%s), want %d+%d",
+				tt.query, qerr.Offset, qerr.Length, qerr.Message, tt.offset, tt.length)
+		}
+	}
+}
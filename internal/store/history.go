@@ -0,0 +1,356 @@
+package store
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// Sample is a timestamped observation in a time series.
+type Sample[T any] struct {
+	Time time.Time `json:"time"`
+	Data T         `json:"data"`
+}
+
+// BoardCounts is the number of issues per status.
+type BoardCounts struct {
+	Counts map[model.Status]int `json:"counts"`
+	Total  int                  `json:"total"`
+}
+
+// AgentState is the recorded state of one Gas Town agent.
+type AgentState struct {
+	Address  string `json:"address"`
+	Role     string `json:"role"`
+	Rig      string `json:"rig,omitempty"`
+	Status   string `json:"status"`
+	Molecule string `json:"molecule,omitempty"`
+}
+
+// ConvoyState is the recorded progress of one convoy.
+type ConvoyState struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Completed int    `json:"completed"`
+	Total     int    `json:"total"`
+	Progress  int    `json:"progress"`
+}
+
+// IssueTransition records an observed change of an issue's status.
+// From is empty the first time gvid sees an issue; Deleted is set when an
+// issue disappears from bd.
+type IssueTransition struct {
+	Time    time.Time    `json:"time"`
+	IssueID string       `json:"issue_id"`
+	Title   string       `json:"title,omitempty"`
+	From    model.Status `json:"from,omitempty"`
+	To      model.Status `json:"to,omitempty"`
+	Deleted bool         `json:"deleted,omitempty"`
+}
+
+// TransitionQuery selects recorded transitions.
+type TransitionQuery struct {
+	IssueID string
+	Since   time.Time
+	Until   time.Time
+}
+
+// TownSnapshot is the recorded state of the board and town at one instant.
+type TownSnapshot struct {
+	At      time.Time               `json:"at"`
+	Board   *Sample[BoardCounts]    `json:"board,omitempty"`
+	Agents  *Sample[[]AgentState]   `json:"agents,omitempty"`
+	Convoys *Sample[[]ConvoyState]  `json:"convoys,omitempty"`
+	Issues  map[string]model.Status `json:"issues,omitempty"`
+}
+
+// History is gvid's embedded time-series store. Board counts, agent
+// statuses and convoy progress are stored as samples, written only when
+// the value changes; a series therefore holds its last value until the
+// next sample. Issue status changes are stored as transitions.
+type History struct {
+	board       *Log[Sample[BoardCounts]]
+	agents      *Log[Sample[[]AgentState]]
+	convoys     *Log[Sample[[]ConvoyState]]
+	transitions *Log[IssueTransition]
+
+	mu         sync.Mutex
+	lastBoard  *BoardCounts
+	lastAgents []AgentState
+	lastConvoy []ConvoyState
+	statuses   map[string]model.Status
+}
+
+// OpenHistory opens the history stored under dataDir/history.
+func OpenHistory(dataDir string) (*History, error) {
+	dir := filepath.Join(dataDir, "history")
+	h := &History{statuses: make(map[string]model.Status)}
+
+	var err error
+	if h.board, err = OpenLog[Sample[BoardCounts]](filepath.Join(dir, "board.jsonl")); err != nil {
+		return nil, err
+	}
+	if h.agents, err = OpenLog[Sample[[]AgentState]](filepath.Join(dir, "agents.jsonl")); err != nil {
+		return nil, err
+	}
+	if h.convoys, err = OpenLog[Sample[[]ConvoyState]](filepath.Join(dir, "convoys.jsonl")); err != nil {
+		return nil, err
+	}
+	if h.transitions, err = OpenLog[IssueTransition](filepath.Join(dir, "transitions.jsonl")); err != nil {
+		return nil, err
+	}
+
+	// Restore the last recorded values so restarts don't duplicate samples
+	if err := h.board.Scan(func(s Sample[BoardCounts]) bool { h.lastBoard = &s.Data; return true }); err != nil {
+		return nil, err
+	}
+	if err := h.agents.Scan(func(s Sample[[]AgentState]) bool { h.lastAgents = s.Data; return true }); err != nil {
+		return nil, err
+	}
+	if err := h.convoys.Scan(func(s Sample[[]ConvoyState]) bool { h.lastConvoy = s.Data; return true }); err != nil {
+		return nil, err
+	}
+	err = h.transitions.Scan(func(t IssueTransition) bool {
+		if t.Deleted {
+			delete(h.statuses, t.IssueID)
+		} else {
+			h.statuses[t.IssueID] = t.To
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Statuses returns the last recorded status of every known issue.
+func (h *History) Statuses() map[string]model.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	statuses := make(map[string]model.Status, len(h.statuses))
+	for id, s := range h.statuses {
+		statuses[id] = s
+	}
+	return statuses
+}
+
+// RecordBoard stores the per-status issue counts if they changed.
+func (h *History) RecordBoard(issues []model.Issue, now time.Time) error {
+	counts := BoardCounts{Counts: make(map[model.Status]int), Total: len(issues)}
+	for _, issue := range issues {
+		counts.Counts[issue.Status]++
+	}
+
+	h.mu.Lock()
+	if h.lastBoard != nil && reflect.DeepEqual(*h.lastBoard, counts) {
+		h.mu.Unlock()
+		return nil
+	}
+	h.lastBoard = &counts
+	h.mu.Unlock()
+
+	return h.board.Append(Sample[BoardCounts]{Time: now, Data: counts})
+}
+
+// RecordTransitions appends issue status transitions.
+func (h *History) RecordTransitions(transitions []IssueTransition) error {
+	h.mu.Lock()
+	for _, t := range transitions {
+		if t.Deleted {
+			delete(h.statuses, t.IssueID)
+		} else {
+			h.statuses[t.IssueID] = t.To
+		}
+	}
+	h.mu.Unlock()
+
+	return h.transitions.Append(transitions...)
+}
+
+// RecordAgents stores agent states if any changed.
+func (h *History) RecordAgents(agents []AgentState, now time.Time) error {
+	h.mu.Lock()
+	if h.lastAgents != nil && sameJSON(h.lastAgents, agents) {
+		h.mu.Unlock()
+		return nil
+	}
+	h.lastAgents = agents
+	h.mu.Unlock()
+
+	return h.agents.Append(Sample[[]AgentState]{Time: now, Data: agents})
+}
+
+// RecordConvoys stores convoy progress if any changed.
+func (h *History) RecordConvoys(convoys []ConvoyState, now time.Time) error {
+	h.mu.Lock()
+	if h.lastConvoy != nil && sameJSON(h.lastConvoy, convoys) {
+		h.mu.Unlock()
+		return nil
+	}
+	h.lastConvoy = convoys
+	h.mu.Unlock()
+
+	return h.convoys.Append(Sample[[]ConvoyState]{Time: now, Data: convoys})
+}
+
+// BoardSeries returns board counts between since and until, downsampled
+// to one point per step (zero step returns every recorded sample).
+func (h *History) BoardSeries(since, until time.Time, step time.Duration) ([]Sample[BoardCounts], error) {
+	return series(h.board, since, until, step)
+}
+
+// AgentSeries returns agent states between since and until.
+func (h *History) AgentSeries(since, until time.Time, step time.Duration) ([]Sample[[]AgentState], error) {
+	return series(h.agents, since, until, step)
+}
+
+// ConvoySeries returns convoy progress between since and until.
+func (h *History) ConvoySeries(since, until time.Time, step time.Duration) ([]Sample[[]ConvoyState], error) {
+	return series(h.convoys, since, until, step)
+}
+
+// Transitions returns recorded transitions matching q, oldest first.
+func (h *History) Transitions(q TransitionQuery) ([]IssueTransition, error) {
+	var results []IssueTransition
+	err := h.transitions.Scan(func(t IssueTransition) bool {
+		if q.IssueID != "" && t.IssueID != q.IssueID {
+			return true
+		}
+		if !inRange(t.Time, q.Since, q.Until) {
+			return true
+		}
+		results = append(results, t)
+		return true
+	})
+	return results, err
+}
+
+// At reconstructs the recorded state at instant t: the last board, agent
+// and convoy samples taken at or before t, and every issue's status.
+func (h *History) At(t time.Time) (*TownSnapshot, error) {
+	snap := &TownSnapshot{At: t, Issues: make(map[string]model.Status)}
+
+	var err error
+	if snap.Board, err = lastBefore(h.board, t); err != nil {
+		return nil, err
+	}
+	if snap.Agents, err = lastBefore(h.agents, t); err != nil {
+		return nil, err
+	}
+	if snap.Convoys, err = lastBefore(h.convoys, t); err != nil {
+		return nil, err
+	}
+
+	err = h.transitions.Scan(func(tr IssueTransition) bool {
+		// Transitions within one snapshot may be out of order, so scan them all
+		if tr.Time.After(t) {
+			return true
+		}
+		if tr.Deleted {
+			delete(snap.Issues, tr.IssueID)
+		} else {
+			snap.Issues[tr.IssueID] = tr.To
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// series reads samples in [since, until] from a log. With a positive step,
+// the range is divided into buckets and each bucket reports the value in
+// effect at its end, carrying the previous value forward across buckets
+// without samples.
+func series[T any](log *Log[Sample[T]], since, until time.Time, step time.Duration) ([]Sample[T], error) {
+	var (
+		results []Sample[T]
+		before  *Sample[T]
+	)
+
+	err := log.Scan(func(s Sample[T]) bool {
+		if !until.IsZero() && s.Time.After(until) {
+			return false
+		}
+		if !since.IsZero() && s.Time.Before(since) {
+			prev := s
+			before = &prev
+			return true
+		}
+		results = append(results, s)
+		return true
+	})
+	if err != nil || step <= 0 {
+		return results, err
+	}
+
+	if since.IsZero() {
+		if len(results) == 0 {
+			return results, nil
+		}
+		since = results[0].Time
+	}
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	var buckets []Sample[T]
+	current := before
+	i := 0
+	for end := since.Add(step); ; end = end.Add(step) {
+		if end.After(until) {
+			end = until
+		}
+		for i < len(results) && !results[i].Time.After(end) {
+			current = &results[i]
+			i++
+		}
+		if current != nil {
+			buckets = append(buckets, Sample[T]{Time: end, Data: current.Data})
+		}
+		if !end.Before(until) {
+			break
+		}
+	}
+	return buckets, nil
+}
+
+// lastBefore returns the last sample taken at or before t.
+func lastBefore[T any](log *Log[Sample[T]], t time.Time) (*Sample[T], error) {
+	var last *Sample[T]
+	err := log.Scan(func(s Sample[T]) bool {
+		if s.Time.After(t) {
+			return false
+		}
+		sample := s
+		last = &sample
+		return true
+	})
+	return last, err
+}
+
+func inRange(t, since, until time.Time) bool {
+	if !since.IsZero() && t.Before(since) {
+		return false
+	}
+	if !until.IsZero() && t.After(until) {
+		return false
+	}
+	return true
+}
+
+func sameJSON(a, b interface{}) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(da) == string(db)
+}
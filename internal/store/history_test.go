@@ -0,0 +1,82 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestHistoryBoardSeriesDownsampling(t *testing.T) {
+	h, err := OpenHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenHistory() error: %v", err)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pending := []model.Issue{{ID: "a", Status: model.StatusPending}, {ID: "b", Status: model.StatusPending}}
+	oneDone := []model.Issue{{ID: "a", Status: model.StatusDone}, {ID: "b", Status: model.StatusPending}}
+
+	_ = h.RecordBoard(pending, base)
+	_ = h.RecordBoard(pending, base.Add(30*time.Minute)) // unchanged, not stored
+	_ = h.RecordBoard(oneDone, base.Add(150*time.Minute))
+
+	raw, err := h.BoardSeries(base, base.Add(4*time.Hour), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 2 {
+		t.Fatalf("expected 2 raw samples, got %d", len(raw))
+	}
+
+	hourly, err := h.BoardSeries(base, base.Add(4*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hourly) != 4 {
+		t.Fatalf("expected 4 hourly buckets, got %d", len(hourly))
+	}
+	// 01:00 and 02:00 carry the first sample forward, 03:00 sees the change
+	wantDone := []int{0, 0, 1, 1}
+	for i, s := range hourly {
+		if got := s.Data.Counts[model.StatusDone]; got != wantDone[i] {
+			t.Errorf("bucket %d (%s): expected %d done, got %d", i, s.Time.Format("15:04"), wantDone[i], got)
+		}
+	}
+}
+
+func TestHistoryAt(t *testing.T) {
+	dir := t.TempDir()
+	h, err := OpenHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_ = h.RecordTransitions([]IssueTransition{
+		{Time: base, IssueID: "gvi-1", To: model.StatusPending},
+		{Time: base.Add(2 * time.Hour), IssueID: "gvi-1", From: model.StatusPending, To: model.StatusInProgress},
+		{Time: base.Add(5 * time.Hour), IssueID: "gvi-1", From: model.StatusInProgress, To: model.StatusDone},
+	})
+	_ = h.RecordAgents([]AgentState{{Address: "gastown/nux", Status: "active"}}, base.Add(time.Hour))
+	_ = h.RecordAgents([]AgentState{{Address: "gastown/nux", Status: "stuck"}}, base.Add(4*time.Hour))
+
+	snap, err := h.At(base.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Issues["gvi-1"] != model.StatusInProgress {
+		t.Errorf("expected gvi-1 in_progress at 03:00, got %s", snap.Issues["gvi-1"])
+	}
+	if snap.Agents == nil || snap.Agents.Data[0].Status != "active" {
+		t.Errorf("expected nux active at 03:00, got %+v", snap.Agents)
+	}
+
+	reopened, err := OpenHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reopened.Statuses()["gvi-1"]; got != model.StatusDone {
+		t.Errorf("expected restored status done, got %s", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Package store provides local persistence for the gvid daemon.
+// Records are kept as append-only JSON Lines files under a data directory,
+// so they survive restarts and can be inspected with ordinary tools.
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Log is an append-only JSON Lines file of records of type T.
+type Log[T any] struct {
+	path string
+	mu   sync.Mutex
+}
+
+// OpenLog opens (creating if necessary) the log file at path.
+func OpenLog[T any](path string) (*Log[T], error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create store directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	f.Close()
+	return &Log[T]{path: path}, nil
+}
+
+// Path returns the file backing the log.
+func (l *Log[T]) Path() string {
+	return l.path
+}
+
+// Append writes records to the end of the log.
+func (l *Log[T]) Append(records ...T) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Scan calls fn for every record in the log, oldest first, until fn
+// returns false. Lines that fail to decode (for example a record truncated
+// by a crash) are skipped.
+func (l *Log[T]) Scan(fn func(T) bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var rec T
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		if !fn(rec) {
+			break
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+)
+
+// MoleculeSnapshot is one observation of a molecule's state.
+type MoleculeSnapshot struct {
+	ObservedAt time.Time        `json:"observed_at"`
+	Molecule   gastown.Molecule `json:"molecule"`
+}
+
+// ArchivedMolecule is the most recent snapshot of a molecule together with
+// when gvid first and last observed it.
+type ArchivedMolecule struct {
+	Molecule  gastown.Molecule `json:"molecule"`
+	FirstSeen time.Time        `json:"first_seen"`
+	LastSeen  time.Time        `json:"last_seen"`
+}
+
+// MoleculeQuery selects molecules from the archive.
+type MoleculeQuery struct {
+	// Since and Until bound the molecule's active interval; zero means unbounded.
+	Since time.Time
+	Until time.Time
+	// Statuses restricts results to the given statuses; empty means any.
+	Statuses []gastown.MoleculeStatus
+}
+
+// lastSeenInterval is how often the last-seen time of an unchanged molecule
+// is written to disk, which bounds how much of it a crash loses.
+const lastSeenInterval = 10 * time.Minute
+
+// MoleculeArchive records every molecule snapshot gvid observes, so workflow
+// history outlives the agents that ran it.
+type MoleculeArchive struct {
+	log *Log[MoleculeSnapshot]
+
+	mu     sync.RWMutex
+	latest map[string]*ArchivedMolecule
+	digest map[string][]byte
+	saved  map[string]time.Time // last-seen time on disk
+}
+
+// OpenMoleculeArchive opens the archive stored in dataDir and loads the
+// latest snapshot of each molecule.
+func OpenMoleculeArchive(dataDir string) (*MoleculeArchive, error) {
+	log, err := OpenLog[MoleculeSnapshot](filepath.Join(dataDir, "molecules.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	a := &MoleculeArchive{
+		log:    log,
+		latest: make(map[string]*ArchivedMolecule),
+		digest: make(map[string][]byte),
+		saved:  make(map[string]time.Time),
+	}
+
+	err = log.Scan(func(snap MoleculeSnapshot) bool {
+		a.apply(snap, moleculeDigest(snap.Molecule))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Record stores a snapshot of each molecule whose state changed since it
+// was last recorded. Unchanged molecules have their last-seen time
+// refreshed in memory and written to disk every lastSeenInterval.
+func (a *MoleculeArchive) Record(molecules []gastown.Molecule, now time.Time) error {
+	var snaps []MoleculeSnapshot
+
+	a.mu.Lock()
+	for _, mol := range molecules {
+		mol.Archived = false
+		d := moleculeDigest(mol)
+		if existing, ok := a.latest[mol.ID]; ok && bytes.Equal(a.digest[mol.ID], d) {
+			existing.LastSeen = now
+			if now.Sub(a.saved[mol.ID]) < lastSeenInterval {
+				continue
+			}
+		}
+		snap := MoleculeSnapshot{ObservedAt: now, Molecule: mol}
+		a.apply(snap, d)
+		snaps = append(snaps, snap)
+	}
+	a.mu.Unlock()
+
+	return a.log.Append(snaps...)
+}
+
+// Flush writes the last-seen time of each molecule seen since it was last
+// written. The daemon calls it on shutdown so a restart keeps it.
+func (a *MoleculeArchive) Flush() error {
+	var snaps []MoleculeSnapshot
+
+	a.mu.Lock()
+	for id, entry := range a.latest {
+		if entry.LastSeen.After(a.saved[id]) {
+			snaps = append(snaps, MoleculeSnapshot{ObservedAt: entry.LastSeen, Molecule: entry.Molecule})
+			a.saved[id] = entry.LastSeen
+		}
+	}
+	a.mu.Unlock()
+
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].Molecule.ID < snaps[j].Molecule.ID
+	})
+	return a.log.Append(snaps...)
+}
+
+// apply folds a snapshot into the in-memory index. Callers hold a.mu or
+// have exclusive access.
+func (a *MoleculeArchive) apply(snap MoleculeSnapshot, digest []byte) {
+	entry, ok := a.latest[snap.Molecule.ID]
+	if !ok {
+		entry = &ArchivedMolecule{FirstSeen: snap.ObservedAt}
+		a.latest[snap.Molecule.ID] = entry
+	}
+	entry.Molecule = snap.Molecule
+	if snap.ObservedAt.After(entry.LastSeen) {
+		entry.LastSeen = snap.ObservedAt
+	}
+	a.digest[snap.Molecule.ID] = digest
+	a.saved[snap.Molecule.ID] = entry.LastSeen
+}
+
+// Get returns the latest archived state of a molecule.
+func (a *MoleculeArchive) Get(id string) (*ArchivedMolecule, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	entry, ok := a.latest[id]
+	if !ok {
+		return nil, false
+	}
+	result := *entry
+	return &result, true
+}
+
+// Query returns archived molecules matching q, most recently seen first.
+func (a *MoleculeArchive) Query(q MoleculeQuery) []ArchivedMolecule {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var results []ArchivedMolecule
+	for _, entry := range a.latest {
+		if !matchesStatus(entry.Molecule.Status, q.Statuses) {
+			continue
+		}
+		if !overlaps(entry.Started(), entry.LastSeen, q.Since, q.Until) {
+			continue
+		}
+		results = append(results, *entry)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].LastSeen.After(results[j].LastSeen)
+	})
+	return results
+}
+
+// Started returns when the molecule began: its creation time if known,
+// otherwise when gvid first saw it.
+func (m *ArchivedMolecule) Started() time.Time {
+	if !m.Molecule.CreatedAt.IsZero() {
+		return m.Molecule.CreatedAt
+	}
+	return m.FirstSeen
+}
+
+// moleculeDigest returns a comparable encoding of a molecule's state.
+func moleculeDigest(mol gastown.Molecule) []byte {
+	data, _ := json.Marshal(mol)
+	return data
+}
+
+func matchesStatus(status gastown.MoleculeStatus, want []gastown.MoleculeStatus) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, s := range want {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether [start, end] intersects [since, until], where a
+// zero bound is unbounded.
+func overlaps(start, end, since, until time.Time) bool {
+	if !since.IsZero() && end.Before(since) {
+		return false
+	}
+	if !until.IsZero() && start.After(until) {
+		return false
+	}
+	return true
+}
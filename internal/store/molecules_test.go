@@ -0,0 +1,155 @@
+package store
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+)
+
+func TestMoleculeArchiveRecordAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	archive, err := OpenMoleculeArchive(dir)
+	if err != nil {
+		t.Fatalf("OpenMoleculeArchive() error: %v", err)
+	}
+
+	mol := gastown.Molecule{ID: "mol-1", Status: gastown.MolStatusInProgress, Formula: "release"}
+	if err := archive.Record([]gastown.Molecule{mol}, base); err != nil {
+		t.Fatal(err)
+	}
+	// Unchanged state must not add a second snapshot
+	if err := archive.Record([]gastown.Molecule{mol}, base.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	mol.Status = gastown.MolStatusComplete
+	if err := archive.Record([]gastown.Molecule{mol}, base.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(archive.log.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("expected 2 snapshots on disk, got %d", lines)
+	}
+
+	reopened, err := OpenMoleculeArchive(dir)
+	if err != nil {
+		t.Fatalf("reopen error: %v", err)
+	}
+	got, ok := reopened.Get("mol-1")
+	if !ok {
+		t.Fatal("expected mol-1 in reopened archive")
+	}
+	if got.Molecule.Status != gastown.MolStatusComplete {
+		t.Errorf("expected latest status complete, got %s", got.Molecule.Status)
+	}
+	if !got.FirstSeen.Equal(base) {
+		t.Errorf("expected first seen %v, got %v", base, got.FirstSeen)
+	}
+}
+
+func TestMoleculeArchiveLastSeenSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	mol := gastown.Molecule{ID: "mol-1", Status: gastown.MolStatusInProgress}
+
+	lastSeen := func(archive *MoleculeArchive) time.Time {
+		t.Helper()
+		got, ok := archive.Get("mol-1")
+		if !ok {
+			t.Fatal("expected mol-1 in archive")
+		}
+		return got.LastSeen
+	}
+	reopen := func() *MoleculeArchive {
+		t.Helper()
+		archive, err := OpenMoleculeArchive(dir)
+		if err != nil {
+			t.Fatalf("OpenMoleculeArchive() error: %v", err)
+		}
+		return archive
+	}
+
+	// An unchanged molecule's last-seen time is written periodically
+	archive := reopen()
+	for _, d := range []time.Duration{0, time.Minute, lastSeenInterval + time.Minute} {
+		if err := archive.Record([]gastown.Molecule{mol}, base.Add(d)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := lastSeen(reopen()), base.Add(lastSeenInterval+time.Minute); !got.Equal(want) {
+		t.Errorf("last seen after crash = %v, want %v", got, want)
+	}
+
+	// and in full on shutdown
+	if err := archive.Record([]gastown.Molecule{mol}, base.Add(lastSeenInterval+2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lastSeen(reopen()), base.Add(lastSeenInterval+2*time.Minute); !got.Equal(want) {
+		t.Errorf("last seen after restart = %v, want %v", got, want)
+	}
+
+	// Flushing again writes nothing new
+	data, err := os.ReadFile(archive.log.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	again, err := os.ReadFile(archive.log.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(data) {
+		t.Errorf("second flush grew the log from %d to %d bytes", len(data), len(again))
+	}
+}
+
+func TestMoleculeArchiveQuery(t *testing.T) {
+	archive, err := OpenMoleculeArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	yesterday := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	today := yesterday.Add(24 * time.Hour)
+
+	_ = archive.Record([]gastown.Molecule{
+		{ID: "done-yesterday", Status: gastown.MolStatusComplete},
+		{ID: "failed-yesterday", Status: gastown.MolStatusFailed},
+	}, yesterday)
+	_ = archive.Record([]gastown.Molecule{
+		{ID: "running-today", Status: gastown.MolStatusInProgress},
+	}, today)
+
+	finished := archive.Query(MoleculeQuery{
+		Statuses: []gastown.MoleculeStatus{gastown.MolStatusComplete, gastown.MolStatusFailed},
+	})
+	if len(finished) != 2 {
+		t.Errorf("expected 2 finished molecules, got %d", len(finished))
+	}
+
+	window := archive.Query(MoleculeQuery{
+		Since: yesterday.Add(-time.Hour),
+		Until: yesterday.Add(time.Hour),
+	})
+	if len(window) != 2 {
+		t.Errorf("expected 2 molecules active yesterday, got %d", len(window))
+	}
+
+	recent := archive.Query(MoleculeQuery{Since: today.Add(-time.Hour)})
+	if len(recent) != 1 || recent[0].Molecule.ID != "running-today" {
+		t.Errorf("expected only running-today since this morning, got %+v", recent)
+	}
+}
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+var (
+	// ErrViewNotFound is returned for a view name that is not stored.
+	ErrViewNotFound = errors.New("view not found")
+	// ErrViewExists is returned when creating a view whose name is taken.
+	ErrViewExists = errors.New("view already exists")
+)
+
+// ViewStore keeps saved views in a single JSON file. Unlike the append-only
+// logs, views are edited in place, so the whole file is rewritten on every
+// change.
+type ViewStore struct {
+	path string
+
+	mu    sync.RWMutex
+	views map[string]model.SavedView
+}
+
+// OpenViewStore opens the views stored in dataDir, creating the directory
+// if necessary.
+func OpenViewStore(dataDir string) (*ViewStore, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("create store directory: %w", err)
+	}
+
+	s := &ViewStore{
+		path:  filepath.Join(dataDir, "views.json"),
+		views: make(map[string]model.SavedView),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", s.path, err)
+	}
+
+	var views []model.SavedView
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	for _, v := range views {
+		s.views[v.Name] = v
+	}
+	return s, nil
+}
+
+// List returns all views sorted by name.
+func (s *ViewStore) List() []model.SavedView {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sorted()
+}
+
+// Get returns the view with the given name.
+func (s *ViewStore) Get(name string) (model.SavedView, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.views[name]
+	if !ok {
+		return model.SavedView{}, ErrViewNotFound
+	}
+	return v, nil
+}
+
+// Create stores a new view. It fails with ErrViewExists if the name is taken.
+func (s *ViewStore) Create(v model.SavedView, now time.Time) (model.SavedView, error) {
+	if err := v.Validate(); err != nil {
+		return model.SavedView{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.views[v.Name]; ok {
+		return model.SavedView{}, ErrViewExists
+	}
+	v.CreatedAt = now
+	v.UpdatedAt = now
+	return v, s.commit(v)
+}
+
+// Put creates or replaces a view, keeping the creation time of an existing
+// one.
+func (s *ViewStore) Put(v model.SavedView, now time.Time) (model.SavedView, error) {
+	if err := v.Validate(); err != nil {
+		return model.SavedView{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v.CreatedAt = now
+	if old, ok := s.views[v.Name]; ok {
+		v.CreatedAt = old.CreatedAt
+	}
+	v.UpdatedAt = now
+	return v, s.commit(v)
+}
+
+// Delete removes a view.
+func (s *ViewStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.views[name]
+	if !ok {
+		return ErrViewNotFound
+	}
+	delete(s.views, name)
+	if err := s.save(); err != nil {
+		s.views[name] = old
+		return err
+	}
+	return nil
+}
+
+// commit stores v and saves, rolling back on failure. s.mu must be held.
+func (s *ViewStore) commit(v model.SavedView) error {
+	old, existed := s.views[v.Name]
+	s.views[v.Name] = v
+	if err := s.save(); err != nil {
+		if existed {
+			s.views[v.Name] = old
+		} else {
+			delete(s.views, v.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// save writes all views to a temporary file and renames it into place, so
+// a crash never leaves a partially written file. s.mu must be held.
+func (s *ViewStore) save() error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *ViewStore) sorted() []model.SavedView {
+	views := make([]model.SavedView, 0, len(s.views))
+	for _, v := range s.views {
+		views = append(views, v)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestViewStore(t *testing.T) {
+	dir := t.TempDir()
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	views, err := OpenViewStore(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	standup := model.SavedView{Name: "standup", Priority: []model.Priority{model.PriorityCritical}, Sort: "-updated"}
+	if _, err := views.Create(standup, created); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := views.Create(standup, created); !errors.Is(err, ErrViewExists) {
+		t.Errorf("expected ErrViewExists, got %v", err)
+	}
+	if _, err := views.Create(model.SavedView{Name: "bad name"}, created); err == nil {
+		t.Error("expected an invalid name to be rejected")
+	}
+
+	standup.GroupBy = model.GroupByAssignee
+	if _, err := views.Put(standup, created.Add(time.Hour)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	// Reopen to check persistence
+	views, err = OpenViewStore(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got, err := views.Get("standup")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.GroupBy != model.GroupByAssignee || !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(created.Add(time.Hour)) {
+		t.Errorf("unexpected view after reopen: %+v", got)
+	}
+
+	if err := views.Delete("standup"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := views.Get("standup"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("expected ErrViewNotFound after delete, got %v", err)
+	}
+	if len(views.List()) != 0 {
+		t.Error("expected no views after delete")
+	}
+}
@@ -0,0 +1,405 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// promptKind is the value an action prompt asks for.
+type promptKind int
+
+const (
+	promptNone promptKind = iota
+	promptTitle
+	promptStatus
+	promptDependency
+)
+
+var promptLabels = map[promptKind]string{
+	promptTitle:      "title: ",
+	promptStatus:     "status: ",
+	promptDependency: "blocked by: ",
+}
+
+// action is a change to an issue sent to the daemon. The board and issue
+// are changed optimistically; undo and undoIssue restore them if the
+// daemon rejects the change.
+type action struct {
+	id        string
+	update    model.IssueUpdate
+	dependsOn string // for dependency actions
+
+	applied   *BoardResponse // board with the change applied
+	undo      *BoardResponse
+	undoIssue *model.Issue
+}
+
+// Messages
+type actionDoneMsg struct {
+	action action
+	issue  *model.Issue
+	err    error
+}
+type editorReadyMsg struct {
+	id, path, original string
+}
+type editorDoneMsg struct {
+	id, path, original string
+	err                error
+}
+
+// actionTarget returns the issue actions apply to: the open issue, or the
+// selected card on the board.
+func (m Model) actionTarget() string {
+	if m.view == ViewIssue && m.issue != nil {
+		return m.issue.ID
+	}
+	if m.view == ViewBoard {
+		return m.selectedID()
+	}
+	return ""
+}
+
+// targetSummary returns the board card of an issue, or the open issue.
+func (m Model) targetSummary(id string) (model.IssueSummary, bool) {
+	if m.board != nil {
+		for _, col := range m.board.Columns {
+			for _, issue := range col.Issues {
+				if issue.ID == id {
+					return issue, true
+				}
+			}
+		}
+	}
+	if m.issue != nil && m.issue.ID == id {
+		return m.issue.Summary(), true
+	}
+	return model.IssueSummary{}, false
+}
+
+// updateActionKey starts an action on the target issue. It reports false
+// if the key is not an action key.
+func (m Model) updateActionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	id := m.actionTarget()
+	if id == "" {
+		return m, nil, false
+	}
+	current, _ := m.targetSummary(id)
+
+	switch {
+	case key.Matches(msg, m.keys.MoveLeft), key.Matches(msg, m.keys.MoveRight):
+		if m.view != ViewBoard {
+			return m, nil, false
+		}
+		delta := 1
+		if key.Matches(msg, m.keys.MoveLeft) {
+			delta = -1
+		}
+		target := m.cursor + delta
+		cols := m.columns()
+		if target < 0 || target >= len(cols) {
+			return m, nil, true
+		}
+		status := columnStatus(cols[target])
+		next, cmd := m.apply(action{id: id, update: model.IssueUpdate{Status: &status}})
+		return next, cmd, true
+
+	case key.Matches(msg, m.keys.RaisePriority), key.Matches(msg, m.keys.LowerPriority):
+		level := current.Priority.Level()
+		if level < 0 {
+			level = model.PriorityMedium.Level()
+		}
+		if key.Matches(msg, m.keys.RaisePriority) {
+			level--
+		} else {
+			level++
+		}
+		if level < 0 || level >= len(model.Priorities) {
+			return m, nil, true
+		}
+		priority := model.Priorities[level]
+		next, cmd := m.apply(action{id: id, update: model.IssueUpdate{Priority: &priority}})
+		return next, cmd, true
+
+	case key.Matches(msg, m.keys.EditTitle):
+		return m.openPrompt(promptTitle, current.Title), textinput.Blink, true
+
+	case key.Matches(msg, m.keys.SetStatus):
+		return m.openPrompt(promptStatus, current.RawStatus), textinput.Blink, true
+
+	case key.Matches(msg, m.keys.AddDependency):
+		return m.openPrompt(promptDependency, ""), textinput.Blink, true
+
+	case key.Matches(msg, m.keys.EditDescription):
+		m.notice = ""
+		return m, m.prepareEditor(id), true
+	}
+
+	return m, nil, false
+}
+
+func (m Model) openPrompt(kind promptKind, value string) Model {
+	m.prompting = kind
+	m.prompt.Prompt = promptLabels[kind]
+	m.prompt.SetValue(value)
+	m.prompt.CursorEnd()
+	m.prompt.Focus()
+	m.notice = ""
+	return m
+}
+
+// updatePrompt handles keys while an action prompt is open.
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.prompting = promptNone
+		m.prompt.Blur()
+		return m, nil
+
+	case tea.KeyEnter:
+		kind := m.prompting
+		value := strings.TrimSpace(m.prompt.Value())
+		m.prompting = promptNone
+		m.prompt.Blur()
+
+		id := m.actionTarget()
+		if value == "" || id == "" {
+			return m, nil
+		}
+		switch kind {
+		case promptTitle:
+			return m.apply(action{id: id, update: model.IssueUpdate{Title: &value}})
+		case promptStatus:
+			return m.apply(action{id: id, update: model.IssueUpdate{Status: &value}})
+		case promptDependency:
+			return m.apply(action{id: id, dependsOn: value})
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// apply changes the board and open issue optimistically and sends the
+// action to the daemon.
+func (m Model) apply(a action) (tea.Model, tea.Cmd) {
+	a.undo = m.board
+	a.undoIssue = m.issue
+	m.notice = ""
+
+	if m.board != nil {
+		selected := m.selectedID()
+		m.board = applyToBoard(m.board, a.id, a.update)
+		m.reselect(selected)
+	}
+	if m.issue != nil && m.issue.ID == a.id {
+		issue := *m.issue
+		applyToIssue(&issue, a.update)
+		m.issue = &issue
+	}
+	a.applied = m.board
+
+	client := m.client
+	return m, func() tea.Msg {
+		var issue *model.Issue
+		var err error
+		if a.dependsOn != "" {
+			issue, err = client.AddDependency(a.id, a.dependsOn)
+		} else {
+			issue, err = client.UpdateIssue(a.id, a.update)
+		}
+		return m.tag(actionDoneMsg{action: a, issue: issue, err: err})
+	}
+}
+
+// updateActionDone reconciles the board with the daemon's answer, rolling
+// the optimistic change back if it failed.
+func (m Model) updateActionDone(msg actionDoneMsg) (tea.Model, tea.Cmd) {
+	a := msg.action
+	if msg.err != nil {
+		m.notice = fmt.Sprintf("%s: %v", a.id, msg.err)
+		// Only roll back if nothing has replaced the board since
+		if m.board == a.applied && a.undo != nil {
+			selected := m.selectedID()
+			m.board = a.undo
+			m.reselect(selected)
+		}
+		if m.issue != nil && m.issue.ID == a.id && a.undoIssue != nil {
+			m.issue = a.undoIssue
+		}
+		return m, m.queueRefresh()
+	}
+
+	if m.issue != nil && m.issue.ID == a.id && msg.issue != nil {
+		m.issue = msg.issue
+	}
+	return m, m.queueRefresh()
+}
+
+// columnStatus returns the status to write when a card moves to col.
+func columnStatus(col model.Column) string {
+	if len(col.Statuses) > 0 {
+		return col.Statuses[0]
+	}
+	return string(col.Status)
+}
+
+// columnAccepts reports whether a card with the given status belongs in col.
+func columnAccepts(col model.Column, status string) bool {
+	if strings.EqualFold(string(col.Status), status) {
+		return true
+	}
+	for _, s := range col.Statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyToBoard returns a copy of board with the update applied to an
+// issue, moving its card if the status changes columns.
+func applyToBoard(board *BoardResponse, id string, update model.IssueUpdate) *BoardResponse {
+	next := &BoardResponse{Total: board.Total, Columns: make([]model.Column, len(board.Columns))}
+	from, row := -1, -1
+	for i, col := range board.Columns {
+		col.Issues = append([]model.IssueSummary(nil), col.Issues...)
+		next.Columns[i] = col
+		for j, issue := range col.Issues {
+			if issue.ID == id {
+				from, row = i, j
+			}
+		}
+	}
+	if from < 0 {
+		return next
+	}
+
+	issue := next.Columns[from].Issues[row]
+	if update.Title != nil {
+		issue.Title = *update.Title
+	}
+	if update.Priority != nil {
+		issue.Priority = *update.Priority
+	}
+	next.Columns[from].Issues[row] = issue
+
+	if update.Status == nil {
+		return next
+	}
+	issue.RawStatus = *update.Status
+	next.Columns[from].Issues[row] = issue
+	if columnAccepts(next.Columns[from], *update.Status) {
+		return next
+	}
+	for to, col := range next.Columns {
+		if to == from || !columnAccepts(col, *update.Status) {
+			continue
+		}
+		src := &next.Columns[from]
+		src.Issues = append(src.Issues[:row], src.Issues[row+1:]...)
+		src.Count--
+		issue.Status = col.Status
+		dst := &next.Columns[to]
+		dst.Issues = append([]model.IssueSummary{issue}, dst.Issues...)
+		dst.Count++
+		break
+	}
+	return next
+}
+
+func applyToIssue(issue *model.Issue, update model.IssueUpdate) {
+	if update.Title != nil {
+		issue.Title = *update.Title
+	}
+	if update.Description != nil {
+		issue.Description = *update.Description
+	}
+	if update.Priority != nil {
+		issue.Priority = *update.Priority
+	}
+	if update.Status != nil {
+		issue.RawStatus = *update.Status
+		if s := model.Status(*update.Status); s.Valid() {
+			issue.Status = s
+		}
+	}
+}
+
+// prepareEditor writes the issue's description to a temporary file for
+// editing in $EDITOR.
+func (m Model) prepareEditor(id string) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		issue, err := client.Issue(id)
+		if err != nil {
+			return m.tag(errMsg(err))
+		}
+		f, err := os.CreateTemp("", "gvi-"+strings.ReplaceAll(id, "/", "-")+"-*.md")
+		if err != nil {
+			return m.tag(errMsg(err))
+		}
+		defer f.Close()
+		if _, err := f.WriteString(issue.Description); err != nil {
+			return m.tag(errMsg(err))
+		}
+		return m.tag(editorReadyMsg{id: id, path: f.Name(), original: issue.Description})
+	}
+}
+
+// openEditor suspends the TUI while $EDITOR runs on the description.
+func openEditor(msg editorReadyMsg) tea.Cmd {
+	editor := strings.Fields(os.Getenv("VISUAL"))
+	if len(editor) == 0 {
+		editor = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], msg.path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{id: msg.id, path: msg.path, original: msg.original, err: err}
+	})
+}
+
+// updateEditorDone saves the edited description if it changed.
+func (m Model) updateEditorDone(msg editorDoneMsg) (tea.Model, tea.Cmd) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		m.notice = "editor: " + msg.err.Error()
+		return m, nil
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.notice = err.Error()
+		return m, nil
+	}
+	description := string(data)
+	if description == msg.original {
+		m.notice = "description unchanged"
+		return m, nil
+	}
+	return m.apply(action{id: msg.id, update: model.IssueUpdate{Description: &description}})
+}
+
+// viewPrompt renders the open action prompt and the result of the last
+// action.
+func (m Model) viewPrompt() string {
+	var b strings.Builder
+	if m.prompting != promptNone {
+		b.WriteString(m.prompt.View() + "\n\n")
+	}
+	if m.notice != "" {
+		b.WriteString(errorStyle.Render(m.notice) + "\n\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func actionBoard() *BoardResponse {
+	cols := testColumns()
+	cols[0].Status, cols[0].Statuses, cols[0].Count = model.StatusPending, []string{"open"}, 2
+	cols[1].Status, cols[1].Statuses, cols[1].Count = model.StatusInProgress, []string{"in_progress"}, 1
+	return &BoardResponse{Total: 3, Columns: cols}
+}
+
+func TestApplyToBoard(t *testing.T) {
+	board := actionBoard()
+	status := "in_progress"
+	title := "Add CSV export"
+	next := applyToBoard(board, "gt-1", model.IssueUpdate{Status: &status, Title: &title})
+
+	if got, want := issueIDs(next.Columns), [][]string{{"gt-3"}, {"gt-1", "gt-12"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+	if next.Columns[0].Count != 1 || next.Columns[1].Count != 2 {
+		t.Errorf("counts = %d, %d", next.Columns[0].Count, next.Columns[1].Count)
+	}
+	moved := next.Columns[1].Issues[0]
+	if moved.Title != title || moved.Status != model.StatusInProgress || moved.RawStatus != status {
+		t.Errorf("moved card = %+v", moved)
+	}
+	if got := issueIDs(board.Columns); !reflect.DeepEqual(got, [][]string{{"gt-3", "gt-1"}, {"gt-12"}}) {
+		t.Errorf("applyToBoard modified the board: %v", got)
+	}
+	if board.Columns[0].Issues[1].Title != "Add export" {
+		t.Errorf("applyToBoard modified a card: %+v", board.Columns[0].Issues[1])
+	}
+}
+
+func TestActionRollback(t *testing.T) {
+	m := New("http://localhost:0")
+	m.board = actionBoard()
+	m.issueCur = 1 // gt-1
+
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}
+	next, cmd, ok := m.updateActionKey(key)
+	if !ok || cmd == nil {
+		t.Fatalf("move right was not handled")
+	}
+	m = next.(Model)
+	if m.cursor != 1 || m.selectedID() != "gt-1" {
+		t.Fatalf("cursor did not follow the card: %d, %q", m.cursor, m.selectedID())
+	}
+
+	status := "in_progress"
+	a := action{id: "gt-1", update: model.IssueUpdate{Status: &status}, applied: m.board, undo: actionBoard()}
+	next, _ = m.updateActionDone(actionDoneMsg{action: a, err: errors.New("bd failed")})
+	m = next.(Model)
+	if got := issueIDs(m.board.Columns); !reflect.DeepEqual(got, [][]string{{"gt-3", "gt-1"}, {"gt-12"}}) {
+		t.Errorf("board after rollback = %v", got)
+	}
+	if m.selectedID() != "gt-1" || m.notice == "" {
+		t.Errorf("after rollback: selected %q, notice %q", m.selectedID(), m.notice)
+	}
+}
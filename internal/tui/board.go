@@ -0,0 +1,338 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// sortOrder orders the cards within each board column.
+type sortOrder int
+
+const (
+	sortBoard sortOrder = iota // as returned by the daemon
+	sortPriority
+	sortUpdated
+	sortID
+	sortTitle
+)
+
+var sortLabels = []string{"board", "priority", "updated", "id", "title"}
+
+func (s sortOrder) String() string { return sortLabels[s] }
+
+// boardFilter narrows and orders the loaded board locally, without a
+// round trip to the daemon.
+type boardFilter struct {
+	column    string // column key, empty for all columns
+	priority  model.Priority
+	issueType string
+	sort      sortOrder
+	text      string // incremental search over ID and title
+}
+
+// active reports whether the filter hides any cards.
+func (f boardFilter) active() bool {
+	return f.column != "" || f.priority != "" || f.issueType != "" || f.text != ""
+}
+
+// describe summarizes the filter for the board title.
+func (f boardFilter) describe(cols []model.Column) string {
+	var parts []string
+	for _, col := range cols {
+		if f.column != "" && columnKey(col) == f.column {
+			parts = append(parts, "column: "+col.Label)
+		}
+	}
+	if f.priority != "" {
+		parts = append(parts, "priority: "+string(f.priority))
+	}
+	if f.issueType != "" {
+		parts = append(parts, "type: "+f.issueType)
+	}
+	if f.sort != sortBoard {
+		parts = append(parts, "sort: "+f.sort.String())
+	}
+	return strings.Join(parts, "  ")
+}
+
+// columnKey identifies a column across board refreshes.
+func columnKey(col model.Column) string {
+	if col.ID != "" {
+		return col.ID
+	}
+	return col.Label
+}
+
+// apply returns the columns and cards that pass the filter, sorted.
+// The board's columns are left untouched.
+func (f boardFilter) apply(cols []model.Column) []model.Column {
+	terms := searchTerms(f.text)
+	var out []model.Column
+	for _, col := range cols {
+		if f.column != "" && columnKey(col) != f.column {
+			continue
+		}
+		issues := make([]model.IssueSummary, 0, len(col.Issues))
+		for _, issue := range col.Issues {
+			if f.priority != "" && issue.Priority != f.priority {
+				continue
+			}
+			if f.issueType != "" && issue.IssueType != f.issueType {
+				continue
+			}
+			if !matchesTerms(issue, terms) {
+				continue
+			}
+			issues = append(issues, issue)
+		}
+		sortIssues(issues, f.sort)
+		col.Issues = issues
+		out = append(out, col)
+	}
+	return out
+}
+
+// searchTerms extracts the free-text words of a query. Field terms and
+// operators are left to the daemon when the query is applied.
+func searchTerms(text string) []string {
+	var terms []string
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		word = strings.Trim(word, `"()`)
+		if word == "" || word == "or" || word == "and" || word == "not" ||
+			strings.HasPrefix(word, "-") || strings.Contains(word, ":") {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// matchesTerms reports whether every term occurs in the issue's ID or title.
+func matchesTerms(issue model.IssueSummary, terms []string) bool {
+	id := strings.ToLower(issue.ID)
+	title := strings.ToLower(issue.Title)
+	for _, term := range terms {
+		if !strings.Contains(id, term) && !strings.Contains(title, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortIssues(issues []model.IssueSummary, order sortOrder) {
+	var less func(a, b model.IssueSummary) bool
+	switch order {
+	case sortPriority:
+		less = func(a, b model.IssueSummary) bool { return a.Priority.Level() < b.Priority.Level() }
+	case sortUpdated:
+		less = func(a, b model.IssueSummary) bool {
+			if a.UpdatedAt == nil || b.UpdatedAt == nil {
+				return a.UpdatedAt != nil
+			}
+			return a.UpdatedAt.After(*b.UpdatedAt)
+		}
+	case sortID:
+		less = func(a, b model.IssueSummary) bool { return a.ID < b.ID }
+	case sortTitle:
+		less = func(a, b model.IssueSummary) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	default:
+		return
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return less(issues[i], issues[j]) })
+}
+
+// nextValue returns the value after current in values, cycling through
+// "" (no filter) at the end.
+func nextValue(values []string, current string) string {
+	for i, v := range values {
+		if v == current {
+			if i+1 < len(values) {
+				return values[i+1]
+			}
+			return ""
+		}
+	}
+	if len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// boardTypes lists the issue types on the board, sorted.
+func boardTypes(cols []model.Column) []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, col := range cols {
+		for _, issue := range col.Issues {
+			if issue.IssueType != "" && !seen[issue.IssueType] {
+				seen[issue.IssueType] = true
+				types = append(types, issue.IssueType)
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// findIssue locates an issue on the board by ID. An exact match wins;
+// otherwise a unique case-insensitive match of the ID's suffix is
+// accepted, so "42" finds "gt-42".
+func findIssue(cols []model.Column, id string) (col, row int, ok bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return 0, 0, false
+	}
+	matches := 0
+	for i, c := range cols {
+		for j, issue := range c.Issues {
+			lower := strings.ToLower(issue.ID)
+			if lower == id {
+				return i, j, true
+			}
+			if strings.HasSuffix(lower, "-"+id) || strings.HasSuffix(lower, "."+id) {
+				col, row = i, j
+				matches++
+			}
+		}
+	}
+	return col, row, matches == 1
+}
+
+// columns returns the board's columns as filtered and sorted locally.
+func (m Model) columns() []model.Column {
+	if m.board == nil {
+		return nil
+	}
+	return m.filter.apply(m.board.Columns)
+}
+
+// refilter replaces the local filter, keeping the selected card if it is
+// still shown.
+func (m Model) refilter(f boardFilter) (tea.Model, tea.Cmd) {
+	selected := m.selectedID()
+	m.filter = f
+	m.reselect(selected)
+	return m, nil
+}
+
+// moveCursor moves the card cursor within the current column.
+func (m *Model) moveCursor(msg tea.KeyMsg) {
+	cols := m.columns()
+	if m.cursor >= len(cols) {
+		return
+	}
+	col := cols[m.cursor]
+	last := len(col.Issues) - 1
+	top := m.top[columnKey(col)]
+	page := max(visibleEnd(col.Issues, top, m.boardLines())-top, 1)
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.issueCur--
+	case key.Matches(msg, m.keys.Down):
+		m.issueCur++
+	case key.Matches(msg, m.keys.PageUp):
+		m.issueCur -= page
+	case key.Matches(msg, m.keys.PageDown):
+		m.issueCur += page
+	case key.Matches(msg, m.keys.Home):
+		m.issueCur = 0
+	case key.Matches(msg, m.keys.End):
+		m.issueCur = last
+	}
+	m.issueCur = max(min(m.issueCur, last), 0)
+	m.scrollToCursor()
+}
+
+// boardLines is the number of lines available for cards in a column.
+func (m Model) boardLines() int {
+	// Tabs, title, filter line, column borders and header, more markers, help
+	lines := m.height - 14
+	if m.searching || m.jumping || m.prompting != promptNone {
+		lines -= 3
+	}
+	if len(m.endpoints) > 1 {
+		lines-- // status bar
+	}
+	return max(lines, 3)
+}
+
+// cardLines is the height of a card on the board.
+func cardLines(issue model.IssueSummary) int {
+	if issue.Agent != nil {
+		return 2
+	}
+	return 1
+}
+
+// visibleEnd returns the index after the last card that fits in lines
+// when the column is scrolled to top. At least one card is always shown.
+func visibleEnd(issues []model.IssueSummary, top, lines int) int {
+	end, used := top, 0
+	for end < len(issues) {
+		used += cardLines(issues[end])
+		if used > lines && end > top {
+			break
+		}
+		end++
+	}
+	return end
+}
+
+// scrollToCursor scrolls the current column so the selected card is shown.
+func (m *Model) scrollToCursor() {
+	cols := m.columns()
+	if m.cursor >= len(cols) {
+		return
+	}
+	col := cols[m.cursor]
+	k := columnKey(col)
+	top := min(m.top[k], max(len(col.Issues)-1, 0))
+	if m.issueCur < top {
+		top = m.issueCur
+	}
+	for top < m.issueCur && visibleEnd(col.Issues, top, m.boardLines()) <= m.issueCur {
+		top++
+	}
+	m.top[k] = top
+}
+
+// updateJump handles keys while the jump-to-ID prompt is open. Issues
+// hidden by the local filter are revealed by clearing it; issues that are
+// not on the board at all are opened directly.
+func (m Model) updateJump(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.jumping = false
+		m.jump.Blur()
+		return m, nil
+
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.jump.Value())
+		m.jumping = false
+		m.jump.Blur()
+		if value == "" {
+			return m, nil
+		}
+
+		col, row, ok := findIssue(m.board.Columns, value)
+		if !ok {
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.fetchIssue(value))
+		}
+		id := m.board.Columns[col].Issues[row].ID
+		if _, _, shown := findIssue(m.columns(), id); !shown {
+			m.filter = boardFilter{sort: m.filter.sort}
+		}
+		m.reselect(id)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.jump, cmd = m.jump.Update(msg)
+	return m, cmd
+}
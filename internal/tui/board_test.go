@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func testColumns() []model.Column {
+	return []model.Column{
+		{ID: "todo", Label: "To Do", Issues: []model.IssueSummary{
+			{ID: "gt-3", Title: "Fix login", Priority: model.PriorityLow, IssueType: "bug"},
+			{ID: "gt-1", Title: "Add export", Priority: model.PriorityHigh, IssueType: "feature"},
+		}},
+		{ID: "doing", Label: "Doing", Issues: []model.IssueSummary{
+			{ID: "gt-12", Title: "Login page", Priority: model.PriorityCritical, IssueType: "feature"},
+		}},
+	}
+}
+
+func issueIDs(cols []model.Column) [][]string {
+	var out [][]string
+	for _, col := range cols {
+		ids := []string{}
+		for _, issue := range col.Issues {
+			ids = append(ids, issue.ID)
+		}
+		out = append(out, ids)
+	}
+	return out
+}
+
+func TestBoardFilterApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter boardFilter
+		want   [][]string
+	}{
+		{"none", boardFilter{}, [][]string{{"gt-3", "gt-1"}, {"gt-12"}}},
+		{"text", boardFilter{text: "LOGIN"}, [][]string{{"gt-3"}, {"gt-12"}}},
+		{"id text", boardFilter{text: "gt-1"}, [][]string{{"gt-1"}, {"gt-12"}}},
+		{"field terms ignored", boardFilter{text: `status:done "login" -page`}, [][]string{{"gt-3"}, {"gt-12"}}},
+		{"column", boardFilter{column: "doing"}, [][]string{{"gt-12"}}},
+		{"priority", boardFilter{priority: model.PriorityHigh}, [][]string{{"gt-1"}, {}}},
+		{"type", boardFilter{issueType: "feature"}, [][]string{{"gt-1"}, {"gt-12"}}},
+		{"sort priority", boardFilter{sort: sortPriority}, [][]string{{"gt-1", "gt-3"}, {"gt-12"}}},
+		{"sort id", boardFilter{sort: sortID}, [][]string{{"gt-1", "gt-3"}, {"gt-12"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols := testColumns()
+			if got := issueIDs(tt.filter.apply(cols)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply = %v, want %v", got, tt.want)
+			}
+			if got := issueIDs(cols); !reflect.DeepEqual(got, [][]string{{"gt-3", "gt-1"}, {"gt-12"}}) {
+				t.Errorf("apply modified the board: %v", got)
+			}
+		})
+	}
+}
+
+func TestFindIssue(t *testing.T) {
+	cols := testColumns()
+	tests := []struct {
+		id       string
+		col, row int
+		ok       bool
+	}{
+		{"gt-1", 0, 1, true},
+		{"GT-12", 1, 0, true},
+		{"3", 0, 0, true},
+		{"12", 1, 0, true},
+		{"gt", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		col, row, ok := findIssue(cols, tt.id)
+		if ok != tt.ok || (ok && (col != tt.col || row != tt.row)) {
+			t.Errorf("findIssue(%q) = %d, %d, %v; want %d, %d, %v", tt.id, col, row, ok, tt.col, tt.row, tt.ok)
+		}
+	}
+}
+
+func TestBoardScrolling(t *testing.T) {
+	col := model.Column{ID: "doing", Label: "Doing"}
+	for i := 0; i < 80; i++ {
+		col.Issues = append(col.Issues, model.IssueSummary{ID: fmt.Sprintf("gt-%d", i), Title: "Issue"})
+	}
+
+	m := New("http://localhost:0")
+	m.height = 24
+	m.board = &BoardResponse{Columns: []model.Column{col}}
+	lines := m.boardLines()
+
+	end := tea.KeyMsg{Type: tea.KeyEnd}
+	m.moveCursor(end)
+	if m.issueCur != 79 {
+		t.Fatalf("cursor after end = %d, want 79", m.issueCur)
+	}
+	top := m.top["doing"]
+	if visibleEnd(col.Issues, top, lines) != 80 || top == 0 {
+		t.Errorf("last card not visible: top %d, %d lines", top, lines)
+	}
+
+	m.moveCursor(tea.KeyMsg{Type: tea.KeyHome})
+	if m.issueCur != 0 || m.top["doing"] != 0 {
+		t.Errorf("home: cursor %d, top %d", m.issueCur, m.top["doing"])
+	}
+
+	m.moveCursor(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.issueCur != lines {
+		t.Errorf("page down: cursor %d, want %d", m.issueCur, lines)
+	}
+	if top := m.top["doing"]; m.issueCur < top || m.issueCur >= visibleEnd(col.Issues, top, lines) {
+		t.Errorf("cursor %d outside window from %d", m.issueCur, top)
+	}
+}
@@ -2,109 +2,205 @@
 package tui
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+	"math/rand/v2"
 	"time"
 
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	gvid "github.com/intent-solutions-io/gastown-viewer-intent/pkg/client"
 )
 
+// Backend is the source of the TUI's data: a gvid daemon (Client), or
+// beads and Gas Town read in-process (Local).
+type Backend interface {
+	// Board fetches the board, filtered by the named saved view and the
+	// query q when they are not empty.
+	Board(view, q string) (*BoardResponse, error)
+
+	// Graph fetches the dependency graph, filtered like Board.
+	Graph(view, q string) (*model.Graph, error)
+
+	// Issue fetches a single issue by ID.
+	Issue(id string) (*model.Issue, error)
+
+	// UpdateIssue applies a partial update to an issue and returns the result.
+	UpdateIssue(id string, update model.IssueUpdate) (*model.Issue, error)
+
+	// AddDependency records that issue id is blocked by dependsOn.
+	AddDependency(id, dependsOn string) (*model.Issue, error)
+
+	// Town fetches the town structure: rigs and their agents.
+	Town() (*gastown.Town, error)
+
+	// Convoys fetches the active convoys.
+	Convoys() ([]gastown.Convoy, error)
+
+	// Molecules fetches the active molecules with their steps.
+	Molecules() ([]gastown.Molecule, error)
+
+	// Mail fetches the inbox of an agent address such as "gastown/nux".
+	Mail(address string) ([]gastown.Message, error)
+
+	// Views fetches the saved views.
+	Views() ([]model.SavedView, error)
+
+	// Events streams issue changes to ch until ctx is done.
+	Events(ctx context.Context, ch chan<- StreamEvent)
+}
+
 // Client fetches data from the gvid daemon API.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL string
+	api     *gvid.Client
 }
 
 // NewClient creates a new API client.
 func NewClient(baseURL string) *Client {
 	return &Client{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		api:     gvid.New(baseURL, gvid.WithTimeout(10*time.Second), gvid.WithUserAgent("gvi-tui")),
 	}
 }
 
-// HealthResponse matches the API health response.
-type HealthResponse struct {
-	Status           string `json:"status"`
-	BeadsInitialized bool   `json:"beads_initialized"`
-	Version          string `json:"version"`
-	BDVersion        string `json:"bd_version,omitempty"`
-	Error            string `json:"error,omitempty"`
+// BoardResponse matches the API board response.
+type BoardResponse struct {
+	Columns []model.Column `json:"columns"`
+	Total   int            `json:"total"`
 }
 
-// Health checks the daemon health.
-func (c *Client) Health() (*HealthResponse, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/v1/health")
-	if err != nil {
-		return nil, fmt.Errorf("connection failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+// Board fetches the board view, filtered by the named saved view and the
+// query q when they are not empty.
+func (c *Client) Board(view, q string) (*BoardResponse, error) {
+	board, err := c.api.Board(context.Background(), gvid.IssueQuery{View: view, Q: q})
 	if err != nil {
 		return nil, err
 	}
+	return &BoardResponse{Columns: board.Columns, Total: board.Total}, nil
+}
 
-	var health HealthResponse
-	if err := json.Unmarshal(body, &health); err != nil {
-		return nil, err
-	}
+// Graph fetches the dependency graph, filtered like Board.
+func (c *Client) Graph(view, q string) (*model.Graph, error) {
+	return c.api.Graph(context.Background(), gvid.IssueQuery{View: view, Q: q})
+}
 
-	return &health, nil
+// Issue fetches a single issue by ID.
+func (c *Client) Issue(id string) (*model.Issue, error) {
+	return c.api.Issue(context.Background(), id)
 }
 
-// BoardResponse matches the API board response.
-type BoardResponse struct {
-	Columns []model.Column `json:"columns"`
-	Total   int            `json:"total"`
+// UpdateIssue applies a partial update to an issue and returns the result.
+func (c *Client) UpdateIssue(id string, update model.IssueUpdate) (*model.Issue, error) {
+	return c.api.UpdateIssue(context.Background(), id, update)
 }
 
-// Board fetches the board view.
-func (c *Client) Board() (*BoardResponse, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/v1/board")
-	if err != nil {
-		return nil, fmt.Errorf("connection failed: %w", err)
-	}
-	defer resp.Body.Close()
+// AddDependency records that issue id is blocked by dependsOn.
+func (c *Client) AddDependency(id, dependsOn string) (*model.Issue, error) {
+	return c.api.AddDependency(context.Background(), id, model.Dependency{DependsOn: dependsOn})
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// TownStatus fetches the town's health and agent counts.
+func (c *Client) TownStatus() (*gastown.TownStatus, error) {
+	return c.api.TownStatus(context.Background())
+}
 
-	var board BoardResponse
-	if err := json.Unmarshal(body, &board); err != nil {
-		return nil, err
-	}
+// Town fetches the town structure: rigs and their agents.
+func (c *Client) Town() (*gastown.Town, error) {
+	return c.api.Town(context.Background())
+}
 
-	return &board, nil
+// Convoys fetches the active convoys.
+func (c *Client) Convoys() ([]gastown.Convoy, error) {
+	return c.api.Convoys(context.Background())
 }
 
-// Issue fetches a single issue by ID.
-func (c *Client) Issue(id string) (*model.Issue, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/v1/issues/" + id)
-	if err != nil {
-		return nil, fmt.Errorf("connection failed: %w", err)
-	}
-	defer resp.Body.Close()
+// Molecules fetches the active molecules with their steps.
+func (c *Client) Molecules() ([]gastown.Molecule, error) {
+	return c.api.Molecules(context.Background(), gvid.MoleculeQuery{})
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("issue not found: %s", id)
+// Mail fetches the inbox of an agent address such as "gastown/nux".
+func (c *Client) Mail(address string) ([]gastown.Message, error) {
+	return c.api.Mail(context.Background(), address)
+}
+
+// Views fetches the saved views. It returns an error if the daemon runs
+// without a data directory.
+func (c *Client) Views() ([]model.SavedView, error) {
+	return c.api.Views(context.Background())
+}
+
+// Stream event types added by the client to report the connection state.
+const (
+	EventConnected    = gvid.EventConnected
+	EventDisconnected = "disconnected"
+)
+
+// StreamEvent is an event from the daemon's SSE stream, or a change in the
+// state of the connection to it.
+type StreamEvent struct {
+	Type    string
+	IssueID string        // for issue events
+	Err     error         // for EventDisconnected
+	Retry   time.Duration // for EventDisconnected, delay before reconnecting
+}
+
+// Reconnection backoff bounds for the event stream.
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Events streams events from /api/v1/events to ch until ctx is done. It
+// reconnects after failures with jittered exponential backoff, reset once
+// a connection succeeds.
+func (c *Client) Events(ctx context.Context, ch chan<- StreamEvent) {
+	backoff := minBackoff
+	for {
+		connected, err := c.stream(ctx, ch)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = minBackoff
+		}
+
+		// Up to 20% jitter keeps a wall of TUIs from reconnecting in step
+		retry := backoff + time.Duration(rand.Int64N(int64(backoff)/5+1))
+		select {
+		case ch <- StreamEvent{Type: EventDisconnected, Err: err, Retry: retry}:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, maxBackoff)
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+// stream reads one SSE connection, reporting whether it connected.
+func (c *Client) stream(ctx context.Context, ch chan<- StreamEvent) (bool, error) {
+	sub, err := c.api.Subscribe(ctx)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-
-	var issue model.Issue
-	if err := json.Unmarshal(body, &issue); err != nil {
-		return nil, err
+	defer sub.Close()
+
+	connected := false
+	for sub.Next() {
+		event := StreamEvent{Type: sub.Event().Type, IssueID: sub.Event().IssueID()}
+		if event.Type == EventConnected {
+			connected = true
+		}
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return connected, ctx.Err()
+		}
 	}
-
-	return &issue, nil
+	return connected, sub.Err()
 }
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientEvents(t *testing.T) {
+	var connections atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if connections.Add(1) == 1 {
+			http.Error(w, `{"error":"starting up"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: connected\ndata: {}\n\n")
+		fmt.Fprint(w, "event: heartbeat\ndata: {}\n\n")
+		fmt.Fprint(w, "event: issue_updated\ndata: {\"id\":\"gt-1\",\"status\":\"done\"}\n\n")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ch := make(chan StreamEvent)
+	go NewClient(srv.URL).Events(ctx, ch)
+
+	want := []StreamEvent{
+		{Type: EventDisconnected},
+		{Type: EventConnected},
+		{Type: "issue_updated", IssueID: "gt-1"},
+		{Type: EventDisconnected},
+	}
+	for i, w := range want {
+		select {
+		case got := <-ch:
+			if got.Type != w.Type || got.IssueID != w.IssueID {
+				t.Fatalf("event %d: got %+v, want %+v", i, got, w)
+			}
+			if got.Type == EventDisconnected && (got.Err == nil || got.Retry < minBackoff) {
+				t.Errorf("event %d: expected an error and a retry delay, got %+v", i, got)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Config is the gvi-tui config file.
+type Config struct {
+	// Endpoints are the gvid daemons to switch between, used when -api
+	// is not given.
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+	// Keys remaps bindings by name, such as "up": ["up", "w"].
+	Keys map[string][]string `json:"keys,omitempty"`
+	// Theme is "dark" (the default) or "light".
+	Theme string `json:"theme,omitempty"`
+	// Palette is the status palette: "default" or "colorblind".
+	Palette string `json:"palette,omitempty"`
+	// Colors override individual colors of the theme and palette.
+	Colors Theme `json:"colors,omitempty"`
+	// DefaultView is the tab shown at startup: board, town, convoys,
+	// molecules or mail.
+	DefaultView string `json:"default_view,omitempty"`
+	// RefreshInterval is how often Gas Town data and endpoint statuses
+	// are polled, such as "30s".
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+}
+
+// DefaultConfigPath returns the config file read when -config is not
+// given: gvi-tui/config.json in the user's config directory.
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gvi-tui", "config.json")
+}
+
+// ParseConfig parses and validates a config file.
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("parse config: %w", err)
+	}
+	if len(cfg.Endpoints) > 0 {
+		endpoints, err := checkEndpoints(cfg.Endpoints)
+		if err != nil {
+			return Config{}, fmt.Errorf("config: %w", err)
+		}
+		cfg.Endpoints = endpoints
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the names in the config and that no key is bound twice.
+func (c Config) Validate() error {
+	if c.Theme != "" {
+		if _, ok := themes[c.Theme]; !ok {
+			return fmt.Errorf("config: unknown theme %q, want dark or light", c.Theme)
+		}
+	}
+	if c.Palette != "" {
+		if _, ok := palettes[c.Palette]; !ok {
+			return fmt.Errorf("config: unknown palette %q, want default or colorblind", c.Palette)
+		}
+	}
+	if c.DefaultView != "" {
+		if _, ok := tabView(c.DefaultView); !ok {
+			return fmt.Errorf("config: unknown default_view %q, want board, town, convoys, molecules or mail", c.DefaultView)
+		}
+	}
+	if c.RefreshInterval != "" {
+		d, err := time.ParseDuration(c.RefreshInterval)
+		if err != nil || d < time.Second {
+			return fmt.Errorf("config: refresh_interval %q must be a duration of at least 1s", c.RefreshInterval)
+		}
+	}
+	_, err := c.keyMap()
+	return err
+}
+
+// keyMap returns the default bindings with the config's remappings.
+func (c Config) keyMap() (keyMap, error) {
+	keys := defaultKeys
+	bindings := keys.named()
+	for name, remapped := range c.Keys {
+		b, ok := bindings[name]
+		if !ok {
+			return keyMap{}, fmt.Errorf("config: unknown key binding %q", name)
+		}
+		if len(remapped) == 0 {
+			return keyMap{}, fmt.Errorf("config: key binding %q has no keys", name)
+		}
+		b.SetKeys(remapped...)
+		b.SetHelp(strings.Join(remapped, "/"), b.Help().Desc)
+	}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	bound := make(map[string]string)
+	for _, name := range names {
+		for _, k := range bindings[name].Keys() {
+			if other, ok := bound[k]; ok {
+				return keyMap{}, fmt.Errorf("config: key %q is bound to both %s and %s", k, other, name)
+			}
+			bound[k] = name
+		}
+	}
+	return keys, nil
+}
+
+// named returns the bindings by their config file names.
+func (k *keyMap) named() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"left": &k.Left, "right": &k.Right, "up": &k.Up, "down": &k.Down,
+		"enter": &k.Enter, "back": &k.Back, "refresh": &k.Refresh, "views": &k.Views,
+		"search": &k.Search, "jump": &k.Jump, "graph": &k.Graph, "mode": &k.Mode,
+		"open": &k.Open, "next_tab": &k.NextTab, "prev_tab": &k.PrevTab,
+		"next_town": &k.NextEnd, "prev_town": &k.PrevEnd, "quit": &k.Quit, "help": &k.Help,
+
+		"page_up": &k.PageUp, "page_down": &k.PageDown, "home": &k.Home, "end": &k.End,
+
+		"filter_column": &k.FilterColumn, "filter_priority": &k.FilterPriority,
+		"filter_type": &k.FilterType, "sort": &k.Sort, "clear_filter": &k.ClearFilter,
+
+		"move_left": &k.MoveLeft, "move_right": &k.MoveRight, "set_status": &k.SetStatus,
+		"edit_title": &k.EditTitle, "raise_priority": &k.RaisePriority,
+		"lower_priority": &k.LowerPriority, "add_dependency": &k.AddDependency,
+		"edit_description": &k.EditDescription,
+	}
+}
+
+// tabView returns the tab with the given label, ignoring case.
+func tabView(name string) (View, bool) {
+	for _, t := range tabs {
+		if strings.EqualFold(t.label, name) {
+			return t.view, true
+		}
+	}
+	return 0, false
+}
+
+// WithConfig applies a validated config to the model. The theme applies
+// to every Model, since styles are shared.
+func (m Model) WithConfig(cfg Config) Model {
+	theme := themes["dark"]
+	if t, ok := themes[cfg.Theme]; ok {
+		theme = t
+	}
+	theme = palettes[cfg.Palette].over(theme)
+	applyTheme(cfg.Colors.over(theme))
+	m.spinner.Style = spinnerStyle
+
+	if keys, err := cfg.keyMap(); err == nil {
+		m.keys = keys
+	}
+	if view, ok := tabView(cfg.DefaultView); ok {
+		m.view = view
+	}
+	if d, err := time.ParseDuration(cfg.RefreshInterval); err == nil {
+		m.refresh = d
+	}
+	return m
+}
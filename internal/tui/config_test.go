@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"empty", `{}`, ""},
+		{"full", `{
+			"endpoints": [{"name": "local", "url": "http://localhost:7070/"}],
+			"keys": {"left": ["left", "a"], "right": ["right", "d"], "help": ["f1"]},
+			"theme": "light",
+			"palette": "colorblind",
+			"colors": {"accent": "#ff00ff"},
+			"default_view": "convoys",
+			"refresh_interval": "30s"
+		}`, ""},
+		{"unknown field", `{"colour": "red"}`, "unknown field"},
+		{"unknown theme", `{"theme": "solarized"}`, "unknown theme"},
+		{"unknown palette", `{"palette": "mono"}`, "unknown palette"},
+		{"unknown view", `{"default_view": "graph"}`, "unknown default_view"},
+		{"short interval", `{"refresh_interval": "10ms"}`, "refresh_interval"},
+		{"unknown binding", `{"keys": {"jump_to": [":"]}}`, "unknown key binding"},
+		{"no keys", `{"keys": {"quit": []}}`, "has no keys"},
+		{"conflict", `{"keys": {"up": ["up", "j"]}}`, `key "j" is bound to both`},
+		{"bad endpoint", `{"endpoints": [{"name": "x", "url": "localhost"}]}`, "invalid endpoint URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithConfig(t *testing.T) {
+	defer applyTheme(themes["dark"])
+
+	cfg, err := ParseConfig([]byte(`{
+		"keys": {"left": ["a"], "down": ["s"], "filter_column": ["C"]},
+		"theme": "light",
+		"default_view": "town",
+		"refresh_interval": "1m"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New("http://localhost:0").WithConfig(cfg)
+
+	if m.view != ViewTown || m.refresh != time.Minute {
+		t.Errorf("view %v, refresh %v", m.view, m.refresh)
+	}
+	press := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	if !key.Matches(press("a"), m.keys.Left) || key.Matches(press("h"), m.keys.Left) {
+		t.Errorf("left not remapped: %v", m.keys.Left.Keys())
+	}
+	if m.keys.Down.Help().Key != "s" || m.keys.Down.Help().Desc != "down" {
+		t.Errorf("down help = %+v", m.keys.Down.Help())
+	}
+	if key.Matches(press("a"), defaultKeys.Left) {
+		t.Error("remapping changed the default bindings")
+	}
+}
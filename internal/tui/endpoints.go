@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+)
+
+// Endpoint is a named gvid daemon.
+type Endpoint struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ParseEndpoints parses a comma-separated list of gvid URLs, each
+// optionally named as name=url. Unnamed endpoints are named by host.
+func ParseEndpoints(s string) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if name, rawURL, ok := strings.Cut(part, "="); ok && !strings.Contains(name, "/") {
+			endpoints = append(endpoints, Endpoint{Name: strings.TrimSpace(name), URL: strings.TrimSpace(rawURL)})
+		} else {
+			endpoints = append(endpoints, Endpoint{URL: part})
+		}
+	}
+	return checkEndpoints(endpoints)
+}
+
+// checkEndpoints validates endpoint URLs, names unnamed endpoints by host
+// and rejects duplicate names.
+func checkEndpoints(in []Endpoint) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	seen := make(map[string]bool)
+	for _, e := range in {
+		u, err := url.Parse(e.URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint URL %q", e.URL)
+		}
+		e.URL = strings.TrimRight(e.URL, "/")
+		if e.Name == "" {
+			e.Name = u.Host
+		}
+		if seen[e.Name] {
+			return nil, fmt.Errorf("duplicate endpoint name %q", e.Name)
+		}
+		seen[e.Name] = true
+		endpoints = append(endpoints, e)
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints given")
+	}
+	return endpoints, nil
+}
+
+// endpointStatus is the last town status fetched from an endpoint.
+type endpointStatus struct {
+	status *gastown.TownStatus
+	err    error
+}
+
+// Messages
+type endpointStatusMsg struct {
+	index int
+	endpointStatus
+}
+type statusTickMsg struct{}
+
+func (m Model) statusTick() tea.Cmd {
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg { return statusTickMsg{} })
+}
+
+// fetchStatuses loads the town status of every endpoint. It does nothing
+// with a single endpoint, which has no status bar.
+func (m Model) fetchStatuses() tea.Cmd {
+	if len(m.endpoints) < 2 {
+		return nil
+	}
+	cmds := make([]tea.Cmd, len(m.endpoints))
+	for i, e := range m.endpoints {
+		client := NewClient(e.URL)
+		cmds[i] = func() tea.Msg {
+			status, err := client.TownStatus()
+			return endpointStatusMsg{index: i, endpointStatus: endpointStatus{status, err}}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// switchEndpoint connects to the endpoint at index, dropping everything
+// loaded from the previous one.
+func (m Model) switchEndpoint(index int) (tea.Model, tea.Cmd) {
+	if index < 0 || index >= len(m.endpoints) || index == m.endpoint {
+		return m, nil
+	}
+	m.stopStream()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.endpoint = index
+	m.client = NewClient(m.endpoints[index].URL)
+	m.streamCtx, m.stopStream = ctx, cancel
+	m.events = make(chan StreamEvent, 16)
+	m.live, m.streamErr = false, nil
+	m.changed = make(map[string]time.Time)
+
+	m.view = m.view.tab()
+	m.board, m.issue, m.err = nil, nil, nil
+	m.filter = boardFilter{}
+	m.views, m.viewIdx, m.query = nil, -1, ""
+	m.cursor, m.issueCur = 0, 0
+	m.top = make(map[string]int)
+	m.town, m.convoys, m.molecules = nil, nil, nil
+	m.molecule, m.mail, m.message, m.mailAddr = nil, nil, nil, ""
+	m.rows = make(map[View]int)
+	m.graph, m.graphFocus, m.graphHist = nil, "", nil
+	m.graphCur, m.graphTop = 0, 0
+	m.loading = true
+
+	return m, tea.Batch(m.spinner.Tick, m.fetchBoard, m.fetchViews, m.listen, m.waitForEvent, m.fetchTab())
+}
+
+// switchHint tells the user how to switch away from an endpoint that is down.
+func (m Model) switchHint() string {
+	if len(m.endpoints) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("  Press '%s' to switch to another town.\n", m.keys.NextEnd.Help().Key)
+}
+
+// viewStatusBar renders the health and active agents of each endpoint,
+// marking the connected one.
+func (m Model) viewStatusBar() string {
+	if len(m.endpoints) < 2 {
+		return ""
+	}
+	parts := make([]string, len(m.endpoints))
+	for i, e := range m.endpoints {
+		s := m.statuses[i]
+		var text string
+		switch {
+		case s.err != nil:
+			text = statusBlocked.Render("○ " + e.Name + " down")
+		case s.status == nil:
+			text = labelStyle.Render("○ " + e.Name)
+		case !s.status.Healthy:
+			text = statusInProgress.Render(fmt.Sprintf("● %s %d/%d agents", e.Name, s.status.ActiveAgents, s.status.TotalAgents))
+		default:
+			text = statusDone.Render(fmt.Sprintf("● %s %d/%d agents", e.Name, s.status.ActiveAgents, s.status.TotalAgents))
+		}
+		if i == m.endpoint {
+			text = lipgloss.NewStyle().Underline(true).Render(text)
+		}
+		parts[i] = text
+	}
+	return strings.Join(parts, "  ") + "\n"
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []Endpoint
+		wantErr bool
+	}{
+		{"http://localhost:7070", []Endpoint{{"localhost:7070", "http://localhost:7070"}}, false},
+		{"local=http://localhost:7070, build=http://build:7070/", []Endpoint{
+			{"local", "http://localhost:7070"},
+			{"build", "http://build:7070"},
+		}, false},
+		{"http://gvid:7070/?token=a=b", []Endpoint{{"gvid:7070", "http://gvid:7070/?token=a=b"}}, false},
+		{"local=localhost:7070", nil, true},
+		{"a=http://x:1,a=http://y:1", nil, true},
+		{" , ", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseEndpoints(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEndpoints(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseEndpoints(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSwitchEndpoint(t *testing.T) {
+	m := NewWithEndpoints([]Endpoint{{"local", "http://localhost:0"}, {"build", "http://build:0"}})
+	m.board = actionBoard()
+	m.query = "type:bug"
+	old, oldCtx := m.events, m.streamCtx
+
+	next, _ := m.switchEndpoint(1)
+	m = next.(Model)
+	if m.endpoint != 1 || m.client.(*Client).baseURL != "http://build:0" {
+		t.Fatalf("endpoint = %d, client %v", m.endpoint, m.client)
+	}
+	if m.board != nil || m.query != "" || !m.loading {
+		t.Errorf("state from the previous endpoint kept: board %v, query %q", m.board, m.query)
+	}
+	if m.events == old {
+		t.Error("event channel not replaced")
+	}
+	if oldCtx.Err() == nil {
+		t.Error("first endpoint's stream not stopped")
+	}
+
+	// Events still in flight from the old stream are dropped
+	next, cmd := m.Update(streamMsg{StreamEvent{Type: EventConnected}, old})
+	if m = next.(Model); m.live || cmd != nil {
+		t.Errorf("stale event applied: live %v", m.live)
+	}
+
+	// So are answers to requests made before the switch
+	for _, msg := range []tea.Msg{boardMsg(actionBoard()), issueMsg(&model.Issue{ID: "gt-1"}), errMsg(errors.New("gone"))} {
+		next, _ = m.Update(endpointMsg{0, msg})
+		if m = next.(Model); m.board != nil || m.issue != nil || m.err != nil {
+			t.Errorf("stale %T applied", msg)
+		}
+	}
+	next, _ = m.Update(m.tag(boardMsg(actionBoard())))
+	if m = next.(Model); m.board == nil {
+		t.Error("board from the current endpoint dropped")
+	}
+
+	m.statuses[0] = endpointStatus{err: errors.New("connection refused")}
+	m.statuses[1] = endpointStatus{status: &gastown.TownStatus{Healthy: true, ActiveAgents: 3, TotalAgents: 5}}
+	bar := m.viewStatusBar()
+	if !strings.Contains(bar, "local down") || !strings.Contains(bar, "build 3/5 agents") {
+		t.Errorf("status bar = %q", bar)
+	}
+	m.stopStream()
+}
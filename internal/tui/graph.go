@@ -0,0 +1,495 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// graphMode selects how the dependency graph is drawn.
+type graphMode int
+
+const (
+	// graphTree shows the issues upstream and downstream of a focused issue.
+	graphTree graphMode = iota
+	// graphLayers shows the whole graph in dependency order.
+	graphLayers
+)
+
+// graphExpandDepth is how deep the focus tree is expanded by default.
+const graphExpandDepth = 2
+
+type graphMsg *model.Graph
+
+func (m Model) fetchGraph() tea.Msg {
+	graph, err := m.client.Graph(m.viewName(), m.query)
+	if err != nil {
+		return m.tag(errMsg(err))
+	}
+	return m.tag(graphMsg(graph))
+}
+
+// graphIndex is a dependency graph indexed for traversal. Edges point from
+// the upstream issue to the issue that depends on it.
+type graphIndex struct {
+	nodes map[string]model.GraphNode
+	order []string // node IDs by priority, then ID
+	up    map[string][]model.GraphEdge
+	down  map[string][]model.GraphEdge
+}
+
+func newGraphIndex(g *model.Graph) *graphIndex {
+	gi := &graphIndex{
+		nodes: make(map[string]model.GraphNode, len(g.Nodes)),
+		up:    make(map[string][]model.GraphEdge),
+		down:  make(map[string][]model.GraphEdge),
+	}
+	for _, n := range g.Nodes {
+		gi.nodes[n.ID] = n
+		gi.order = append(gi.order, n.ID)
+	}
+	gi.sortIDs(gi.order)
+
+	for _, e := range g.Edges {
+		if _, ok := gi.nodes[e.From]; !ok {
+			continue
+		}
+		if _, ok := gi.nodes[e.To]; !ok {
+			continue
+		}
+		gi.up[e.To] = append(gi.up[e.To], e)
+		gi.down[e.From] = append(gi.down[e.From], e)
+	}
+	for _, edges := range gi.up {
+		sort.SliceStable(edges, func(i, j int) bool { return gi.less(edges[i].From, edges[j].From) })
+	}
+	for _, edges := range gi.down {
+		sort.SliceStable(edges, func(i, j int) bool { return gi.less(edges[i].To, edges[j].To) })
+	}
+	return gi
+}
+
+func (gi *graphIndex) less(a, b string) bool {
+	pa, pb := gi.nodes[a].Priority.Level(), gi.nodes[b].Priority.Level()
+	if pa != pb {
+		return pa < pb
+	}
+	return a < b
+}
+
+func (gi *graphIndex) sortIDs(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool { return gi.less(ids[i], ids[j]) })
+}
+
+// graphRow is one line of the focus tree.
+type graphRow struct {
+	id       string
+	depth    int            // 0 for the focused issue
+	upstream bool           // whether the row is in the upstream half
+	edge     model.EdgeType // edge to the parent row
+	path     string         // identifies the row for expansion state
+	children bool           // whether the row can be expanded
+	expanded bool
+	cycle    bool // the issue is already an ancestor of this row
+}
+
+// treeRows flattens the focus tree of id: the focused issue, then what it
+// depends on, then what depends on it. open overrides the default
+// expansion of rows by path.
+func (gi *graphIndex) treeRows(id string, open map[string]bool) []graphRow {
+	if _, ok := gi.nodes[id]; !ok {
+		return nil
+	}
+	rows := []graphRow{{id: id}}
+	for _, upstream := range []bool{true, false} {
+		prefix := id + ":down"
+		if upstream {
+			prefix = id + ":up"
+		}
+		rows = gi.walk(rows, id, upstream, 1, prefix, map[string]bool{id: true}, open)
+	}
+	return rows
+}
+
+func (gi *graphIndex) walk(rows []graphRow, id string, upstream bool, depth int, path string,
+	ancestors map[string]bool, open map[string]bool) []graphRow {
+	for _, e := range gi.edges(id, upstream) {
+		next := e.To
+		if upstream {
+			next = e.From
+		}
+		row := graphRow{
+			id:       next,
+			depth:    depth,
+			upstream: upstream,
+			edge:     e.Type,
+			path:     path + "/" + next,
+			cycle:    ancestors[next],
+		}
+		row.children = !row.cycle && len(gi.edges(next, upstream)) > 0
+		row.expanded = row.children && depth <= graphExpandDepth
+		if v, ok := open[row.path]; ok {
+			row.expanded = row.children && v
+		}
+		rows = append(rows, row)
+
+		if row.expanded {
+			ancestors[next] = true
+			rows = gi.walk(rows, next, upstream, depth+1, row.path, ancestors, open)
+			delete(ancestors, next)
+		}
+	}
+	return rows
+}
+
+func (gi *graphIndex) edges(id string, upstream bool) []model.GraphEdge {
+	if upstream {
+		return gi.up[id]
+	}
+	return gi.down[id]
+}
+
+// layers groups the graph in dependency order: each issue sits one layer
+// below the deepest issue it depends on. Issues on or behind a dependency
+// cycle cannot be ordered and are returned separately.
+func (gi *graphIndex) layers() (layers [][]string, cyclic []string) {
+	indegree := make(map[string]int, len(gi.nodes))
+	for id := range gi.nodes {
+		indegree[id] = len(gi.up[id])
+	}
+	depth := make(map[string]int, len(gi.nodes))
+
+	var ready []string
+	for _, id := range gi.order {
+		if indegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		for _, e := range gi.down[id] {
+			depth[e.To] = max(depth[e.To], depth[id]+1)
+			if indegree[e.To]--; indegree[e.To] == 0 {
+				ready = append(ready, e.To)
+			}
+		}
+	}
+
+	for _, id := range gi.order {
+		if indegree[id] > 0 {
+			cyclic = append(cyclic, id)
+			continue
+		}
+		for len(layers) <= depth[id] {
+			layers = append(layers, nil)
+		}
+		layers[depth[id]] = append(layers[depth[id]], id)
+	}
+	return layers, cyclic
+}
+
+// layerIDs lists the graph's issues in layer order.
+func (gi *graphIndex) layerIDs() []string {
+	layers, cyclic := gi.layers()
+	var ids []string
+	for _, layer := range layers {
+		ids = append(ids, layer...)
+	}
+	return append(ids, cyclic...)
+}
+
+// openGraph switches to the graph view focused on id, or to the layered
+// view of the whole graph when id is empty.
+func (m Model) openGraph(id string) (tea.Model, tea.Cmd) {
+	m.view = ViewGraph
+	m.graphFocus = id
+	m.graphMode = graphTree
+	if id == "" {
+		m.graphMode = graphLayers
+	}
+	m.graphHist = nil
+	m.graphCur, m.graphTop = 0, 0
+	m.loading = true
+	return m, tea.Batch(m.spinner.Tick, m.fetchGraph)
+}
+
+// graphSelected returns the issue under the cursor.
+func (m Model) graphSelected() string {
+	if m.graph == nil {
+		return ""
+	}
+	var ids []string
+	if m.graphMode == graphLayers {
+		ids = m.graph.layerIDs()
+	} else {
+		for _, row := range m.graph.treeRows(m.graphFocus, m.graphOpen) {
+			ids = append(ids, row.id)
+		}
+	}
+	if m.graphCur < len(ids) {
+		return ids[m.graphCur]
+	}
+	return ""
+}
+
+// updateGraphKey handles navigation in the graph view.
+func (m Model) updateGraphKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.graph == nil {
+		if key.Matches(msg, m.keys.Back) {
+			m.view = ViewBoard
+		}
+		return m, nil
+	}
+
+	var rows []graphRow
+	count := len(m.graph.nodes)
+	if m.graphMode == graphTree {
+		rows = m.graph.treeRows(m.graphFocus, m.graphOpen)
+		count = len(rows)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.graphCur > 0 {
+			m.graphCur--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.graphCur < count-1 {
+			m.graphCur++
+		}
+
+	case key.Matches(msg, m.keys.PageUp):
+		m.graphCur = max(m.graphCur-m.graphLines(), 0)
+
+	case key.Matches(msg, m.keys.PageDown):
+		m.graphCur = max(min(m.graphCur+m.graphLines(), count-1), 0)
+
+	case key.Matches(msg, m.keys.Home):
+		m.graphCur = 0
+
+	case key.Matches(msg, m.keys.End):
+		m.graphCur = max(count-1, 0)
+
+	case key.Matches(msg, m.keys.Left), key.Matches(msg, m.keys.Right):
+		if m.graphCur < len(rows) && rows[m.graphCur].children {
+			m.graphOpen[rows[m.graphCur].path] = key.Matches(msg, m.keys.Right)
+		}
+
+	case key.Matches(msg, m.keys.Enter):
+		// Refocus the tree on the selected issue
+		if id := m.graphSelected(); id != "" && (id != m.graphFocus || m.graphMode == graphLayers) {
+			m.graphHist = append(m.graphHist, m.graphFocus)
+			m.graphFocus = id
+			m.graphMode = graphTree
+			m.graphCur = 0
+		}
+
+	case key.Matches(msg, m.keys.Open):
+		if id := m.graphSelected(); id != "" {
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.fetchIssue(id))
+		}
+
+	case key.Matches(msg, m.keys.Mode):
+		if m.graphMode == graphTree {
+			m.graphMode = graphLayers
+		} else {
+			m.graphMode = graphTree
+		}
+		m.graphCur = 0
+
+	case key.Matches(msg, m.keys.Back):
+		if n := len(m.graphHist); n > 0 {
+			m.graphFocus = m.graphHist[n-1]
+			m.graphHist = m.graphHist[:n-1]
+			m.graphCur = 0
+		} else {
+			m.view = ViewBoard
+		}
+	}
+
+	m.scrollGraph()
+	return m, nil
+}
+
+// issueStatusStyle colors an issue status.
+func issueStatusStyle(status model.Status) lipgloss.Style {
+	switch status {
+	case model.StatusInProgress:
+		return statusInProgress
+	case model.StatusDone:
+		return statusDone
+	case model.StatusBlocked:
+		return statusBlocked
+	}
+	return statusPending
+}
+
+// graphNodeLine renders an issue as a status-colored line.
+func (gi *graphIndex) graphNodeLine(id string) string {
+	n := gi.nodes[id]
+	title := n.Title
+	if len(title) > 50 {
+		title = title[:47] + "..."
+	}
+	style := issueStatusStyle(n.Status)
+	return fmt.Sprintf("%s %s %s %s", style.Render("●"), style.Render(id), title,
+		labelStyle.Render(fmt.Sprintf("[%s]", n.Priority)))
+}
+
+// graphLines is the number of lines available for the graph.
+func (m Model) graphLines() int {
+	// Tabs, back hint, title, more markers, graph help and key help
+	lines := m.height - 14
+	if len(m.endpoints) > 1 {
+		lines-- // status bar
+	}
+	return max(lines, 3)
+}
+
+// graphBody renders the graph in the current mode, returning its lines and
+// the line of each selectable row.
+func (m Model) graphBody() (lines []string, rowLines []int) {
+	if m.graphMode == graphLayers {
+		return m.graphLayerLines()
+	}
+	return m.graphTreeLines()
+}
+
+// scrollGraph scrolls the graph so the selected row is shown.
+func (m *Model) scrollGraph() {
+	if m.graph == nil {
+		return
+	}
+	lines, rows := m.graphBody()
+	if m.graphCur >= len(rows) {
+		m.graphTop = 0
+		return
+	}
+	cur, visible := rows[m.graphCur], m.graphLines()
+	top := min(m.graphTop, max(len(lines)-visible, 0))
+	switch {
+	case m.graphCur == 0:
+		top = 0 // keep the headers above the first row in view
+	case cur < top:
+		top = cur
+	case cur >= top+visible:
+		top = cur - visible + 1
+	}
+	m.graphTop = top
+}
+
+func (m Model) viewGraph() string {
+	var b strings.Builder
+	b.WriteString(m.backHint())
+
+	mode := "dependencies of " + m.graphFocus
+	if m.graphMode == graphLayers {
+		mode = "all issues in dependency order"
+	}
+	b.WriteString(titleStyle.Render("Graph") + " " + labelStyle.Render(mode) + m.loadingMark() + "\n\n")
+
+	if m.graph == nil {
+		return b.String() + m.viewLoading()
+	}
+	if len(m.graph.nodes) == 0 {
+		return b.String() + labelStyle.Render("No issues.")
+	}
+
+	// Lines, scrolled to the graph's window
+	lines, _ := m.graphBody()
+	top := min(m.graphTop, len(lines))
+	end := min(top+m.graphLines(), len(lines))
+	if top > 0 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("↑ %d more", top)) + "\n")
+	}
+	for _, line := range lines[top:end] {
+		b.WriteString(line + "\n")
+	}
+	if end < len(lines) {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("↓ %d more", len(lines)-end)) + "\n")
+	}
+
+	b.WriteString("\n" + labelStyle.Render("enter: focus  o: open  <-/->: collapse/expand  m: tree/layers"))
+	return b.String()
+}
+
+// graphTreeLines renders the focus tree.
+func (m Model) graphTreeLines() (lines []string, rowLines []int) {
+	rows := m.graph.treeRows(m.graphFocus, m.graphOpen)
+	if len(rows) == 0 {
+		return []string{labelStyle.Render(m.graphFocus + " is not in the graph.")}, nil
+	}
+
+	for i, row := range rows {
+		if row.depth == 1 && (i == 1 || rows[i-1].upstream != row.upstream) {
+			header := "Blocked by"
+			if !row.upstream {
+				header = "Blocks"
+			}
+			lines = append(lines, "", lipgloss.NewStyle().Bold(true).Render(header))
+		}
+
+		marker := "  "
+		switch {
+		case row.cycle:
+			marker = "↺ "
+		case row.children && row.expanded:
+			marker = "▾ "
+		case row.children:
+			marker = "▸ "
+		}
+		line := strings.Repeat("  ", max(row.depth-1, 0)) + marker + m.graph.graphNodeLine(row.id)
+		if row.edge != "" && row.edge != model.EdgeTypeBlocks {
+			line += labelStyle.Render(" (" + string(row.edge) + ")")
+		}
+		rowLines = append(rowLines, len(lines))
+		lines = append(lines, cursorLine(i == m.graphCur, line))
+	}
+	if len(rows) == 1 {
+		lines = append(lines, "", labelStyle.Render("No dependencies."))
+	}
+	return lines, rowLines
+}
+
+// graphLayerLines renders the whole graph in dependency order.
+func (m Model) graphLayerLines() (lines []string, rowLines []int) {
+	gi := m.graph
+	layers, cyclic := gi.layers()
+	if len(cyclic) > 0 {
+		layers = append(layers, cyclic)
+	}
+
+	i := 0
+	for n, layer := range layers {
+		title := fmt.Sprintf("Layer %d", n+1)
+		if len(cyclic) > 0 && n == len(layers)-1 {
+			title = "Cycles"
+		}
+		if n > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, lipgloss.NewStyle().Bold(true).Render(title))
+		for _, id := range layer {
+			line := gi.graphNodeLine(id)
+			if up := gi.up[id]; len(up) > 0 {
+				from := make([]string, len(up))
+				for j, e := range up {
+					from[j] = e.From
+				}
+				line += labelStyle.Render("  ← " + strings.Join(from, ", "))
+			}
+			rowLines = append(rowLines, len(lines))
+			lines = append(lines, cursorLine(i == m.graphCur, line))
+			i++
+		}
+	}
+	return lines, rowLines
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func testGraph() *graphIndex {
+	g := model.NewGraph()
+	for _, id := range []string{"a", "b", "c", "d", "x", "y"} {
+		g.AddNode(model.GraphNode{ID: id, Title: id, Priority: model.PriorityMedium})
+	}
+	// a -> b -> c, a -> c, c -> d, and a cycle x <-> y
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"x", "y"}, {"y", "x"}} {
+		g.AddEdge(model.GraphEdge{From: e[0], To: e[1], Type: model.EdgeTypeBlocks})
+	}
+	g.AddEdge(model.GraphEdge{From: "a", To: "missing", Type: model.EdgeTypeBlocks})
+	return newGraphIndex(&g)
+}
+
+func TestGraphLayers(t *testing.T) {
+	layers, cyclic := testGraph().layers()
+
+	want := [][]string{{"a"}, {"b"}, {"c"}, {"d"}}
+	if !reflect.DeepEqual(layers, want) {
+		t.Errorf("layers = %v, want %v", layers, want)
+	}
+	if !reflect.DeepEqual(cyclic, []string{"x", "y"}) {
+		t.Errorf("cyclic = %v, want [x y]", cyclic)
+	}
+}
+
+func TestGraphTreeRows(t *testing.T) {
+	gi := testGraph()
+
+	ids := func(rows []graphRow) []string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r.id)
+		}
+		return out
+	}
+
+	rows := gi.treeRows("c", nil)
+	if got, want := ids(rows), []string{"c", "a", "b", "a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tree of c = %v, want %v", got, want)
+	}
+	if !rows[1].upstream || rows[1].depth != 1 || rows[3].depth != 2 || rows[4].upstream {
+		t.Errorf("unexpected row placement: %+v", rows)
+	}
+
+	// Collapsing b hides what it depends on
+	open := map[string]bool{rows[2].path: false}
+	if got, want := ids(gi.treeRows("c", open)), []string{"c", "a", "b", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("collapsed tree = %v, want %v", got, want)
+	}
+
+	// Cycles stop at the repeated issue
+	rows = gi.treeRows("x", nil)
+	if got, want := ids(rows), []string{"x", "y", "x", "y", "x"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tree of x = %v, want %v", got, want)
+	}
+	if !rows[2].cycle || rows[2].children || rows[2].expanded {
+		t.Errorf("repeated x should be marked as a cycle: %+v", rows[2])
+	}
+
+	if rows := gi.treeRows("missing", nil); rows != nil {
+		t.Errorf("tree of unknown issue = %v, want nil", rows)
+	}
+}
+
+func TestGraphScrolling(t *testing.T) {
+	g := model.NewGraph()
+	for i := 0; i < 60; i++ {
+		g.AddNode(model.GraphNode{ID: fmt.Sprintf("gt-%02d", i), Title: "Issue", Priority: model.PriorityMedium})
+	}
+
+	m := New("http://localhost:0")
+	m.height = 24
+	m.view = ViewGraph
+	m.graphMode = graphLayers
+	m.graph = newGraphIndex(&g)
+	visible := m.graphLines()
+
+	updated, _ := m.updateGraphKey(tea.KeyMsg{Type: tea.KeyEnd})
+	m = updated.(Model)
+	lines, rows := m.graphBody()
+	if m.graphCur != 59 || m.graphTop != len(lines)-visible {
+		t.Fatalf("end: cursor %d, top %d of %d lines", m.graphCur, m.graphTop, len(lines))
+	}
+	if view := m.viewGraph(); !strings.Contains(view, "gt-59") || strings.Contains(view, "gt-00") {
+		t.Errorf("last row not shown after end:\n%s", view)
+	}
+
+	updated, _ = m.updateGraphKey(tea.KeyMsg{Type: tea.KeyPgUp})
+	m = updated.(Model)
+	if cur := rows[m.graphCur]; cur < m.graphTop || cur >= m.graphTop+visible {
+		t.Errorf("cursor line %d outside window from %d", cur, m.graphTop)
+	}
+
+	updated, _ = m.updateGraphKey(tea.KeyMsg{Type: tea.KeyHome})
+	m = updated.(Model)
+	if m.graphCur != 0 || m.graphTop != 0 {
+		t.Errorf("home: cursor %d, top %d", m.graphCur, m.graphTop)
+	}
+	if view := m.viewGraph(); strings.Count(view, "gt-") > visible || !strings.Contains(view, "more") {
+		t.Errorf("graph not windowed:\n%s", view)
+	}
+}
@@ -0,0 +1,336 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/join"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/query"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// Local timings. bd is slower than the daemon's API, so calls get more
+// time, and changes are found by polling instead of an event stream.
+const (
+	localTimeout      = 30 * time.Second
+	localPollInterval = 5 * time.Second
+)
+
+// LocalConfig configures a Local backend.
+type LocalConfig struct {
+	Dir      string            // beads project directory (default: current directory)
+	TownRoot string            // Gas Town workspace root (default: ~/gt)
+	DataDir  string            // gvid data directory for saved views (empty disables them)
+	Board    model.BoardConfig // board columns (default: four standard columns)
+	Statuses beads.StatusMap   // extra raw bd status mappings
+}
+
+// Local implements Backend in-process, reading beads through the bd CLI
+// and Gas Town from the filesystem, so the TUI works without a daemon.
+type Local struct {
+	beads   beads.Adapter
+	gastown gastown.Adapter
+	board   model.BoardConfig
+	views   *store.ViewStore
+}
+
+// NewLocal creates a Local backend for the beads project in config.Dir.
+func NewLocal(config LocalConfig) (*Local, error) {
+	adapter := beads.NewCLIAdapter(config.Dir)
+	if config.Statuses != nil {
+		adapter.SetStatusMap(config.Statuses)
+	}
+
+	local := NewLocalWithAdapters(adapter, gastown.NewFSAdapter(config.TownRoot))
+	if len(config.Board.Columns) > 0 {
+		local.board = config.Board
+	}
+	if config.DataDir != "" {
+		views, err := store.OpenViewStore(config.DataDir)
+		if err != nil {
+			return nil, fmt.Errorf("open saved views: %w", err)
+		}
+		local.views = views
+	}
+	return local, nil
+}
+
+// NewLocalWithAdapters creates a Local backend over the given adapters
+// (for testing).
+func NewLocalWithAdapters(beadsAdapter beads.Adapter, gtAdapter gastown.Adapter) *Local {
+	return &Local{
+		beads:   beadsAdapter,
+		gastown: gtAdapter,
+		board:   model.DefaultBoardConfig(),
+	}
+}
+
+// localQuery is a saved view and query resolved like gvid's ?view= and ?q=
+// parameters.
+type localQuery struct {
+	filter   model.IssueFilter
+	query    *query.Query
+	sort     string
+	filtered bool
+}
+
+func (l *Local) parseQuery(view, q string) (localQuery, error) {
+	lq := localQuery{filter: model.NewIssueFilter()}
+	if view != "" {
+		if l.views == nil {
+			return localQuery{}, errors.New("saved views are unavailable without a data directory")
+		}
+		v, err := l.views.Get(view)
+		if err != nil {
+			return localQuery{}, err
+		}
+		lq = localQuery{filter: v.Filter(), sort: v.Sort, filtered: true}
+		// Views are validated when saved
+		lq.query, _ = query.Parse(v.Query)
+	}
+	if q != "" {
+		parsed, err := query.Parse(q)
+		if err != nil {
+			return localQuery{}, err
+		}
+		lq.query = parsed
+		lq.filtered = true
+	}
+	return lq, nil
+}
+
+// issues lists the issues matching a query.
+func (l *Local) issues(ctx context.Context, lq localQuery) ([]model.Issue, error) {
+	if err := l.checkInitialized(ctx); err != nil {
+		return nil, err
+	}
+	issues, err := l.beads.ListIssues(ctx, lq.filter)
+	if err != nil {
+		return nil, err
+	}
+	issues = lq.query.Filter(issues, time.Now())
+	model.SortIssues(issues, lq.sort)
+	return issues, nil
+}
+
+// checkInitialized returns an error if bd is missing or beads is not
+// initialized in the project directory.
+func (l *Local) checkInitialized(ctx context.Context) error {
+	initialized, err := l.beads.IsInitialized(ctx)
+	if err != nil {
+		return err
+	}
+	if !initialized {
+		return errors.New("beads not initialized. Run 'bd init' in your project directory")
+	}
+	return nil
+}
+
+// workIndex joins Gas Town agents and convoys to the issues they work on.
+func (l *Local) workIndex(ctx context.Context) *join.Index {
+	agents, _ := l.gastown.Agents(ctx)
+	convoys, _ := l.gastown.Convoys(ctx)
+	molecules, _ := l.gastown.Molecules(ctx)
+	return join.New(agents, convoys, molecules)
+}
+
+// Board implements Backend.Board.
+func (l *Local) Board(view, q string) (*BoardResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	lq, err := l.parseQuery(view, q)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := l.issues(ctx, lq)
+	if err != nil {
+		return nil, err
+	}
+
+	board := model.BuildBoard(l.board, issues, "")
+	l.workIndex(ctx).AnnotateBoard(&board)
+	return &BoardResponse{Columns: board.Columns, Total: board.Total}, nil
+}
+
+// Graph implements Backend.Graph.
+func (l *Local) Graph(view, q string) (*model.Graph, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	lq, err := l.parseQuery(view, q)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkInitialized(ctx); err != nil {
+		return nil, err
+	}
+	graph, err := l.beads.Graph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !lq.filtered {
+		return graph, nil
+	}
+
+	issues, err := l.issues(ctx, lq)
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		keep[issue.ID] = true
+	}
+	sub := graph.SubGraph(keep)
+	return &sub, nil
+}
+
+// Issue implements Backend.Issue.
+func (l *Local) Issue(id string) (*model.Issue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	issue, err := l.beads.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	l.workIndex(ctx).Annotate(issue)
+	return issue, nil
+}
+
+// UpdateIssue implements Backend.UpdateIssue.
+func (l *Local) UpdateIssue(id string, update model.IssueUpdate) (*model.Issue, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	issue, err := l.beads.UpdateIssue(ctx, id, update)
+	if err != nil {
+		return nil, err
+	}
+	l.workIndex(ctx).Annotate(issue)
+	return issue, nil
+}
+
+// AddDependency implements Backend.AddDependency.
+func (l *Local) AddDependency(id, dependsOn string) (*model.Issue, error) {
+	dep := model.Dependency{Issue: id, DependsOn: dependsOn}
+	if err := dep.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+
+	// bd reports unknown issues inconsistently, so check both ends first
+	for _, id := range []string{dep.Issue, dep.DependsOn} {
+		if _, err := l.beads.GetIssue(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.beads.AddDependency(ctx, dep); err != nil {
+		return nil, err
+	}
+	return l.beads.GetIssue(ctx, id)
+}
+
+// Town implements Backend.Town.
+func (l *Local) Town() (*gastown.Town, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+	return l.gastown.Town(ctx)
+}
+
+// Convoys implements Backend.Convoys.
+func (l *Local) Convoys() ([]gastown.Convoy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+	return l.gastown.Convoys(ctx)
+}
+
+// Molecules implements Backend.Molecules.
+func (l *Local) Molecules() ([]gastown.Molecule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+	return l.gastown.Molecules(ctx)
+}
+
+// Mail implements Backend.Mail.
+func (l *Local) Mail(address string) ([]gastown.Message, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), localTimeout)
+	defer cancel()
+	return l.gastown.Mail(ctx, address)
+}
+
+// Views implements Backend.Views.
+func (l *Local) Views() ([]model.SavedView, error) {
+	if l.views == nil {
+		return nil, errors.New("saved views are unavailable without a data directory")
+	}
+	return l.views.List(), nil
+}
+
+// Events implements Backend.Events by polling bd for changed issues. A
+// failed poll is reported as a disconnection and the next successful one
+// as a reconnection.
+func (l *Local) Events(ctx context.Context, ch chan<- StreamEvent) {
+	var known map[string]model.IssueState
+	connected := false
+	send := func(event StreamEvent) bool {
+		select {
+		case ch <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	ticker := time.NewTicker(localPollInterval)
+	defer ticker.Stop()
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, localTimeout)
+		issues, err := l.beads.ListIssues(pollCtx, model.NewIssueFilter())
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err != nil:
+			if !send(StreamEvent{Type: EventDisconnected, Err: err, Retry: localPollInterval}) {
+				return
+			}
+			connected = false
+
+		default:
+			if !connected {
+				if !send(StreamEvent{Type: EventConnected}) {
+					return
+				}
+				connected = true
+			}
+			current := model.IssueStates(issues)
+			// The first poll only establishes the baseline
+			if known != nil {
+				for _, c := range model.DiffIssueStates(known, current) {
+					if !send(StreamEvent{Type: string(c.Type), IssueID: c.ID}) {
+						return
+					}
+				}
+			}
+			known = current
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
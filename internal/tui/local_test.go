@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+func TestLocalBoard(t *testing.T) {
+	mock := beads.NewMockExecutor()
+	mock.SetResponse("status", []byte("ok"))
+	mock.SetResponse("list --json", []byte(`[
+		{"id": "gt-1", "title": "Fix login", "status": "open", "priority": 1, "issue_type": "bug"},
+		{"id": "gt-2", "title": "Add export", "status": "in_progress", "priority": 2, "issue_type": "feature"},
+		{"id": "gt-3", "title": "Old bug", "status": "closed", "priority": 3, "issue_type": "bug"}
+	]`))
+	local := NewLocalWithAdapters(beads.NewCLIAdapterWithExecutor("", mock), gastown.NewFSAdapter(t.TempDir()))
+
+	board, err := local.Board("", "type:bug")
+	if err != nil {
+		t.Fatalf("Board: %v", err)
+	}
+	if board.Total != 2 {
+		t.Errorf("total = %d, want 2", board.Total)
+	}
+	counts := map[model.Status]int{}
+	for _, col := range board.Columns {
+		counts[col.Status] = len(col.Issues)
+	}
+	if counts[model.StatusPending] != 1 || counts[model.StatusDone] != 1 || counts[model.StatusInProgress] != 0 {
+		t.Errorf("column counts = %v", counts)
+	}
+
+	if _, err := local.Board("", "priority:<"); err == nil {
+		t.Error("expected an error for an invalid query")
+	}
+	if _, err := local.Board("mine", ""); err == nil {
+		t.Error("expected an error for a saved view without a data directory")
+	}
+}
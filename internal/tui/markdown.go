@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/markdown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+)
+
+// maxDescriptionLines caps the rendered description in the detail view.
+const maxDescriptionLines = 30
+
+// Markdown styles
+var (
+	mdHeadingStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("39"))
+
+	mdCodeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("180"))
+
+	mdCodeBlockStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("180")).
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(lipgloss.Color("240")).
+				PaddingLeft(1)
+
+	mdQuoteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Italic(true)
+
+	mdLinkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("39")).
+			Underline(true)
+
+	mdRefStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205"))
+)
+
+// renderMarkdown renders a description for the terminal, highlighting the
+// IDs link accepts.
+func renderMarkdown(src string, link markdown.Linker) string {
+	r := mdRenderer{link: link}
+	lines := strings.Split(r.blocks(markdown.Parse(src), ""), "\n")
+	if len(lines) > maxDescriptionLines {
+		lines = append(lines[:maxDescriptionLines], labelStyle.Render("..."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type mdRenderer struct {
+	link markdown.Linker
+}
+
+func (r mdRenderer) blocks(blocks []markdown.Block, indent string) string {
+	var parts []string
+	for _, block := range blocks {
+		switch block.Kind {
+		case markdown.BlockParagraph:
+			var lines []string
+			for _, line := range strings.Split(block.Text, "\n") {
+				lines = append(lines, indent+r.inline(line))
+			}
+			parts = append(parts, strings.Join(lines, "\n"))
+
+		case markdown.BlockHeading:
+			parts = append(parts, indent+mdHeadingStyle.Render(markdown.PlainText(markdown.ParseInline(block.Text, nil))))
+
+		case markdown.BlockCode:
+			parts = append(parts, mdCodeBlockStyle.Render(block.Text))
+
+		case markdown.BlockList:
+			var lines []string
+			for _, item := range block.Items {
+				marker := "•"
+				switch {
+				case item.Checkbox && item.Checked:
+					marker = statusDone.Render("[x]")
+				case item.Checkbox:
+					marker = "[ ]"
+				case item.Ordered:
+					marker = fmt.Sprintf("%d.", item.Number)
+				}
+				lines = append(lines, indent+strings.Repeat("  ", item.Level)+marker+" "+r.inline(item.Text))
+			}
+			parts = append(parts, strings.Join(lines, "\n"))
+
+		case markdown.BlockQuote:
+			parts = append(parts, mdQuoteStyle.Render(r.blocks(block.Children, indent+"│ ")))
+
+		case markdown.BlockRule:
+			parts = append(parts, labelStyle.Render(strings.Repeat("─", 20)))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (r mdRenderer) inline(text string) string {
+	var b strings.Builder
+	for _, in := range markdown.ParseInline(text, r.link) {
+		switch in.Kind {
+		case markdown.InlineCode:
+			b.WriteString(mdCodeStyle.Render(in.Text))
+		case markdown.InlineStrong:
+			b.WriteString(lipgloss.NewStyle().Bold(true).Render(markdown.PlainText(in.Children)))
+		case markdown.InlineEm:
+			b.WriteString(lipgloss.NewStyle().Italic(true).Render(markdown.PlainText(in.Children)))
+		case markdown.InlineLink:
+			label := markdown.PlainText(in.Children)
+			b.WriteString(mdLinkStyle.Render(label))
+			if label != in.Href {
+				b.WriteString(labelStyle.Render(" (" + in.Href + ")"))
+			}
+		case markdown.InlineRef:
+			b.WriteString(mdRefStyle.Render(in.Text))
+		default:
+			b.WriteString(in.Text)
+		}
+	}
+	return b.String()
+}
+
+// sameRigLinker accepts IDs from the same rig as issueID. The terminal has
+// nothing to link to, so the target is only used for highlighting.
+func sameRigLinker(issueID string) markdown.Linker {
+	rig := model.IssueRig(issueID)
+	return func(id string) (string, bool) {
+		return id, rig != "" && model.IssueRig(id) == rig
+	}
+}
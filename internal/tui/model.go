@@ -1,15 +1,20 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
 	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/query"
 )
 
 // View represents the current view mode.
@@ -18,14 +23,22 @@ type View int
 const (
 	ViewBoard View = iota
 	ViewIssue
+	ViewTown
+	ViewConvoys
+	ViewMolecules
+	ViewMolecule
+	ViewMail
+	ViewMessage
+	ViewGraph
 )
 
 // Model is the main TUI model.
 type Model struct {
-	client   *Client
+	client   Backend
 	board    *BoardResponse
 	issue    *model.Issue
 	view     View
+	issueOf  View // view the open issue returns to
 	err      error
 	loading  bool
 	spinner  spinner.Model
@@ -33,10 +46,73 @@ type Model struct {
 	keys     keyMap
 	cursor   int // selected column
 	issueCur int // selected issue in column
+	views    []model.SavedView
+	viewIdx  int // selected saved view, -1 for all issues
 	width    int
 	height   int
+
+	// Search prompt
+	searching bool
+	search    textinput.Model
+	query     string       // applied query
+	queryErr  *query.Error // syntax error in the prompt
+
+	// Local filtering and scrolling
+	filter  boardFilter
+	jumping bool
+	jump    textinput.Model
+	top     map[string]int // column key -> first visible card
+
+	// Issue actions
+	prompting promptKind
+	prompt    textinput.Model
+	notice    string // result of the last action, cleared on the next key
+
+	// Live updates
+	events        chan StreamEvent
+	streamCtx     context.Context // stops the stream feeding events
+	stopStream    context.CancelFunc
+	live          bool
+	streamErr     error                // last stream failure, nil once connected
+	retry         time.Duration        // delay before the next reconnect
+	changed       map[string]time.Time // issue ID -> last change, for highlighting
+	refreshQueued bool
+
+	// Gas Town tabs
+	town      *gastown.Town
+	convoys   []gastown.Convoy
+	molecules []gastown.Molecule
+	molecule  *gastown.Molecule // molecule open in the step viewer
+	mailAddr  string            // mailbox being read, empty for the address list
+	mail      []gastown.Message
+	message   *gastown.Message
+	rows      map[View]int    // selected row per view
+	expanded  map[string]bool // convoy ID -> issues shown
+
+	// Dependency graph
+	graph      *graphIndex
+	graphFocus string   // issue at the root of the tree
+	graphHist  []string // previous focuses, for going back
+	graphCur   int
+	graphTop   int // first visible line of the graph
+	graphMode  graphMode
+	graphOpen  map[string]bool // tree row path -> expanded
+
+	// Endpoints
+	endpoints []Endpoint // named daemons to switch between, if more than one
+	endpoint  int
+	statuses  []endpointStatus // per endpoint, for the status bar
+
+	refresh time.Duration // how often Gas Town data and statuses are polled
 }
 
+const (
+	// refreshDelay coalesces bursts of events into one board refresh.
+	refreshDelay = 250 * time.Millisecond
+	// highlightDuration is how long changed cards stay highlighted.
+	highlightDuration = 3 * time.Second
+)
+
 // keyMap defines keybindings.
 type keyMap struct {
 	Left    key.Binding
@@ -46,18 +122,53 @@ type keyMap struct {
 	Enter   key.Binding
 	Back    key.Binding
 	Refresh key.Binding
+	Views   key.Binding
+	Search  key.Binding
+	Jump    key.Binding
+	Graph   key.Binding
+	Mode    key.Binding
+	Open    key.Binding
+	NextTab key.Binding
+	NextEnd key.Binding
+	PrevEnd key.Binding
+	PrevTab key.Binding
 	Quit    key.Binding
 	Help    key.Binding
+
+	PageUp   key.Binding
+	PageDown key.Binding
+	Home     key.Binding
+	End      key.Binding
+
+	FilterColumn   key.Binding
+	FilterPriority key.Binding
+	FilterType     key.Binding
+	Sort           key.Binding
+	ClearFilter    key.Binding
+
+	MoveLeft        key.Binding
+	MoveRight       key.Binding
+	SetStatus       key.Binding
+	EditTitle       key.Binding
+	RaisePriority   key.Binding
+	LowerPriority   key.Binding
+	AddDependency   key.Binding
+	EditDescription key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Enter, k.Quit, k.Help}
+	return []key.Binding{k.Left, k.Right, k.Enter, k.Search, k.Quit, k.Help}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Left, k.Right, k.Up, k.Down},
-		{k.Enter, k.Back, k.Refresh, k.Quit},
+		{k.Left, k.Right, k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
+		{k.Enter, k.Back, k.Refresh, k.Views, k.Search, k.Jump},
+		{k.FilterColumn, k.FilterPriority, k.FilterType, k.Sort, k.ClearFilter},
+		{k.MoveLeft, k.MoveRight, k.SetStatus, k.EditTitle, k.RaisePriority, k.LowerPriority,
+			k.AddDependency, k.EditDescription},
+		{k.Graph, k.Mode, k.Open},
+		{k.NextTab, k.PrevTab, k.NextEnd, k.PrevEnd, k.Quit},
 	}
 }
 
@@ -69,66 +180,248 @@ var defaultKeys = keyMap{
 	Enter:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
 	Back:    key.NewBinding(key.WithKeys("esc", "backspace"), key.WithHelp("esc", "back")),
 	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Views:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next view")),
+	Search:  key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "query")),
+	Jump:    key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "jump to ID")),
+	Graph:   key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "graph")),
+	Mode:    key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "tree/layers")),
+	Open:    key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open issue")),
+	NextTab: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next tab")),
+	NextEnd: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next town")),
+	PrevEnd: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "prev town")),
+	PrevTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev tab")),
 	Quit:    key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
 	Help:    key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+
+	PageUp:   key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
+	PageDown: key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
+	Home:     key.NewBinding(key.WithKeys("home"), key.WithHelp("home", "first")),
+	End:      key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("end/G", "last")),
+
+	FilterColumn:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "filter column")),
+	FilterPriority: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "filter priority")),
+	FilterType:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "filter type")),
+	Sort:           key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "sort")),
+	ClearFilter:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear filters")),
+
+	MoveLeft:        key.NewBinding(key.WithKeys("shift+left", "H"), key.WithHelp("H", "move left")),
+	MoveRight:       key.NewBinding(key.WithKeys("shift+right", "L"), key.WithHelp("L", "move right")),
+	SetStatus:       key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "set status")),
+	EditTitle:       key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit title")),
+	RaisePriority:   key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "raise priority")),
+	LowerPriority:   key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "lower priority")),
+	AddDependency:   key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "add blocker")),
+	EditDescription: key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit description")),
 }
 
-// New creates a new TUI model.
+// New creates a new TUI model for the gvid daemon at apiURL.
 func New(apiURL string) Model {
+	return NewWithBackend(NewClient(apiURL))
+}
+
+// NewWithEndpoints creates a new TUI model connected to the first of
+// several gvid daemons, with a status bar covering all of them.
+func NewWithEndpoints(endpoints []Endpoint) Model {
+	m := New(endpoints[0].URL)
+	m.endpoints = endpoints
+	m.statuses = make([]endpointStatus, len(endpoints))
+	return m
+}
+
+// NewWithBackend creates a new TUI model that reads from backend.
+func NewWithBackend(backend Backend) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = spinnerStyle
+
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "status:in_progress priority:<=high updated:<7d \"text\""
+
+	jump := textinput.New()
+	jump.Prompt = ": "
+	jump.Placeholder = "issue ID"
+
+	// The stream runs until the program exits or the endpoint is switched
+	streamCtx, stopStream := context.WithCancel(context.Background())
 
 	return Model{
-		client:  NewClient(apiURL),
+		client:  backend,
 		spinner: s,
+		search:  search,
+		events:  make(chan StreamEvent, 16),
+		changed: make(map[string]time.Time),
 		help:    help.New(),
 		keys:    defaultKeys,
 		loading: true,
+		viewIdx: -1,
 		width:   80,
 		height:  24,
+
+		streamCtx:  streamCtx,
+		stopStream: stopStream,
+
+		rows:     make(map[View]int),
+		expanded: make(map[string]bool),
+
+		graphOpen: make(map[string]bool),
+
+		jump:   jump,
+		top:    make(map[string]int),
+		prompt: textinput.New(),
+
+		refresh: defaultRefresh,
 	}
 }
 
 // Messages
 type boardMsg *BoardResponse
 type issueMsg *model.Issue
+type viewsMsg []model.SavedView
+type issueRefreshMsg *model.Issue
+type streamMsg struct {
+	event StreamEvent
+	ch    chan StreamEvent // stream the event came from
+}
+type refreshMsg struct{}
+type highlightMsg struct{}
 type errMsg error
 
+// endpointMsg is the result of a command started while connected to an
+// endpoint. Results that arrive after switching to another endpoint are
+// dropped, like events from its stream.
+type endpointMsg struct {
+	index int
+	msg   tea.Msg
+}
+
+// tag marks msg as coming from the current endpoint.
+func (m Model) tag(msg tea.Msg) tea.Msg {
+	return endpointMsg{m.endpoint, msg}
+}
+
 func (m Model) fetchBoard() tea.Msg {
-	board, err := m.client.Board()
+	board, err := m.client.Board(m.viewName(), m.query)
 	if err != nil {
-		return errMsg(err)
+		return m.tag(errMsg(err))
 	}
-	return boardMsg(board)
+	return m.tag(boardMsg(board))
+}
+
+// refreshIssue reloads the open issue in place after it changes.
+func (m Model) refreshIssue(id string) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := m.client.Issue(id)
+		if err != nil {
+			return m.tag(errMsg(err))
+		}
+		return m.tag(issueRefreshMsg(issue))
+	}
+}
+
+// listen starts streaming backend events into m.events.
+func (m Model) listen() tea.Msg {
+	client, ctx, ch := m.client, m.streamCtx, m.events
+	go func() {
+		client.Events(ctx, ch)
+		close(ch)
+	}()
+	return nil
+}
+
+// waitForEvent delivers the next stream event as a message.
+func (m Model) waitForEvent() tea.Msg {
+	event, ok := <-m.events
+	if !ok {
+		return nil
+	}
+	return streamMsg{event, m.events}
+}
+
+// fetchViews loads the saved views. Views are optional, so failures leave
+// the board unfiltered instead of reporting an error.
+func (m Model) fetchViews() tea.Msg {
+	views, err := m.client.Views()
+	if err != nil {
+		return nil
+	}
+	return m.tag(viewsMsg(views))
+}
+
+// viewName returns the name of the selected saved view, or "" for all issues.
+func (m Model) viewName() string {
+	if m.viewIdx < 0 || m.viewIdx >= len(m.views) {
+		return ""
+	}
+	return m.views[m.viewIdx].Name
 }
 
 func (m Model) fetchIssue(id string) tea.Cmd {
 	return func() tea.Msg {
 		issue, err := m.client.Issue(id)
 		if err != nil {
-			return errMsg(err)
+			return m.tag(errMsg(err))
 		}
-		return issueMsg(issue)
+		return m.tag(issueMsg(issue))
 	}
 }
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, m.fetchBoard)
+	cmds := []tea.Cmd{m.spinner.Tick, m.fetchBoard, m.fetchViews, m.listen, m.waitForEvent, m.townTick()}
+	if m.view.isTown() {
+		cmds = append(cmds, m.fetchTab())
+	}
+	if len(m.endpoints) > 1 {
+		cmds = append(cmds, m.fetchStatuses(), m.statusTick())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case endpointMsg:
+		if msg.index != m.endpoint {
+			return m, nil
+		}
+		return m.Update(msg.msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.help.Width = msg.Width
+		m.scrollToCursor()
+		m.scrollGraph()
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.jumping {
+			return m.updateJump(msg)
+		}
+		if m.prompting != promptNone {
+			return m.updatePrompt(msg)
+		}
+		m.notice = ""
+		if m.view == ViewBoard || m.view == ViewIssue {
+			if next, cmd, ok := m.updateActionKey(msg); ok {
+				return next, cmd
+			}
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Search):
+			if m.view != ViewBoard {
+				return m, nil
+			}
+			m.searching = true
+			m.search.SetValue(m.query)
+			m.search.CursorEnd()
+			return m, m.search.Focus()
+
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
@@ -136,59 +429,134 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.help.ShowAll = !m.help.ShowAll
 			return m, nil
 
+		case key.Matches(msg, m.keys.NextTab):
+			return m.switchTab(1)
+
+		case key.Matches(msg, m.keys.PrevTab):
+			return m.switchTab(-1)
+
+		case key.Matches(msg, m.keys.NextEnd), key.Matches(msg, m.keys.PrevEnd):
+			if len(m.endpoints) < 2 {
+				return m, nil
+			}
+			delta := 1
+			if key.Matches(msg, m.keys.PrevEnd) {
+				delta = -1
+			}
+			return m.switchEndpoint((m.endpoint + delta + len(m.endpoints)) % len(m.endpoints))
+
 		case key.Matches(msg, m.keys.Refresh):
 			m.loading = true
 			m.err = nil
+			if m.view.isTown() {
+				return m, tea.Batch(m.spinner.Tick, m.fetchTab())
+			}
+			if m.view == ViewGraph {
+				return m, tea.Batch(m.spinner.Tick, m.fetchGraph)
+			}
+			return m, tea.Batch(m.spinner.Tick, m.fetchBoard, m.fetchViews)
+
+		case m.view.isTown():
+			return m.updateTownKey(msg)
+
+		case m.view == ViewGraph:
+			return m.updateGraphKey(msg)
+
+		case key.Matches(msg, m.keys.Graph):
+			id := m.selectedID()
+			if m.view == ViewIssue && m.issue != nil {
+				id = m.issue.ID
+			}
+			return m.openGraph(id)
+
+		case key.Matches(msg, m.keys.Views):
+			if m.view != ViewBoard || len(m.views) == 0 {
+				return m, nil
+			}
+			// Cycle through the saved views, then back to all issues
+			m.viewIdx++
+			if m.viewIdx >= len(m.views) {
+				m.viewIdx = -1
+			}
+			m.cursor = 0
+			m.issueCur = 0
+			m.loading = true
 			return m, tea.Batch(m.spinner.Tick, m.fetchBoard)
 
 		case key.Matches(msg, m.keys.Back):
 			if m.view == ViewIssue {
-				m.view = ViewBoard
+				m.view = m.issueOf
 				m.issue = nil
 			}
 			return m, nil
 
+		case m.view != ViewBoard || m.board == nil:
+			return m, nil
+
 		case key.Matches(msg, m.keys.Left):
-			if m.view == ViewBoard && m.board != nil && m.cursor > 0 {
+			if m.cursor > 0 {
 				m.cursor--
 				m.issueCur = 0
+				m.scrollToCursor()
 			}
 			return m, nil
 
 		case key.Matches(msg, m.keys.Right):
-			if m.view == ViewBoard && m.board != nil && m.cursor < len(m.board.Columns)-1 {
+			if m.cursor < len(m.columns())-1 {
 				m.cursor++
 				m.issueCur = 0
+				m.scrollToCursor()
 			}
 			return m, nil
 
-		case key.Matches(msg, m.keys.Up):
-			if m.view == ViewBoard && m.issueCur > 0 {
-				m.issueCur--
-			}
+		case key.Matches(msg, m.keys.Up), key.Matches(msg, m.keys.Down),
+			key.Matches(msg, m.keys.PageUp), key.Matches(msg, m.keys.PageDown),
+			key.Matches(msg, m.keys.Home), key.Matches(msg, m.keys.End):
+			m.moveCursor(msg)
 			return m, nil
 
-		case key.Matches(msg, m.keys.Down):
-			if m.view == ViewBoard && m.board != nil && m.cursor < len(m.board.Columns) {
-				col := m.board.Columns[m.cursor]
-				if m.issueCur < len(col.Issues)-1 {
-					m.issueCur++
-				}
+		case key.Matches(msg, m.keys.Enter):
+			if id := m.selectedID(); id != "" {
+				m.loading = true
+				return m, tea.Batch(m.spinner.Tick, m.fetchIssue(id))
 			}
 			return m, nil
 
-		case key.Matches(msg, m.keys.Enter):
-			if m.view == ViewBoard && m.board != nil {
-				if m.cursor < len(m.board.Columns) {
-					col := m.board.Columns[m.cursor]
-					if m.issueCur < len(col.Issues) {
-						issue := col.Issues[m.issueCur]
-						m.loading = true
-						return m, tea.Batch(m.spinner.Tick, m.fetchIssue(issue.ID))
-					}
-				}
+		case key.Matches(msg, m.keys.Jump):
+			m.jumping = true
+			m.jump.SetValue("")
+			return m, m.jump.Focus()
+
+		case key.Matches(msg, m.keys.FilterColumn):
+			f := m.filter
+			var keys []string
+			for _, col := range m.board.Columns {
+				keys = append(keys, columnKey(col))
 			}
-			return m, nil
+			f.column = nextValue(keys, f.column)
+			return m.refilter(f)
+
+		case key.Matches(msg, m.keys.FilterPriority):
+			f := m.filter
+			var priorities []string
+			for _, p := range model.Priorities {
+				priorities = append(priorities, string(p))
+			}
+			f.priority = model.Priority(nextValue(priorities, string(f.priority)))
+			return m.refilter(f)
+
+		case key.Matches(msg, m.keys.FilterType):
+			f := m.filter
+			f.issueType = nextValue(boardTypes(m.board.Columns), f.issueType)
+			return m.refilter(f)
+
+		case key.Matches(msg, m.keys.Sort):
+			f := m.filter
+			f.sort = (f.sort + 1) % sortOrder(len(sortLabels))
+			return m.refilter(f)
+
+		case key.Matches(msg, m.keys.ClearFilter):
+			return m.refilter(boardFilter{})
 		}
 
 	case spinner.TickMsg:
@@ -197,18 +565,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case boardMsg:
+		selected := m.selectedID()
 		m.loading = false
 		m.board = msg
 		m.err = nil
+		m.reselect(selected)
+		return m, nil
+
+	case streamMsg:
+		if msg.ch != m.events {
+			// Left over from an endpoint switched away from
+			return m, nil
+		}
+		return m.updateStream(msg.event)
+
+	case refreshMsg:
+		m.refreshQueued = false
+		if m.view == ViewGraph {
+			return m, tea.Batch(m.fetchBoard, m.fetchGraph)
+		}
+		return m, m.fetchBoard
+
+	case graphMsg:
+		m.loading = false
+		m.err = nil
+		m.graph = newGraphIndex(msg)
+		if m.graphCur >= len(m.graph.nodes) {
+			m.graphCur = 0
+		}
+		m.scrollGraph()
+		return m, nil
+
+	case highlightMsg:
+		for id, at := range m.changed {
+			if time.Since(at) >= highlightDuration {
+				delete(m.changed, id)
+			}
+		}
+		return m, nil
+
+	case issueRefreshMsg:
+		if m.view == ViewIssue && m.issue != nil && m.issue.ID == msg.ID {
+			m.issue = msg
+		}
+		return m, nil
+
+	case viewsMsg:
+		selected := m.viewName()
+		m.views = msg
+		m.viewIdx = -1
+		for i, v := range m.views {
+			if v.Name == selected {
+				m.viewIdx = i
+			}
+		}
 		return m, nil
 
 	case issueMsg:
 		m.loading = false
 		m.issue = msg
+		if m.view != ViewIssue {
+			m.issueOf = m.view
+		}
 		m.view = ViewIssue
 		m.err = nil
 		return m, nil
 
+	case actionDoneMsg:
+		return m.updateActionDone(msg)
+
+	case editorReadyMsg:
+		return m, openEditor(msg)
+
+	case editorDoneMsg:
+		return m.updateEditorDone(msg)
+
+	case townMsg, convoysMsg, moleculesMsg, mailMsg:
+		return m.updateTownData(msg)
+
+	case endpointStatusMsg:
+		if msg.index < len(m.statuses) {
+			m.statuses[msg.index] = msg.endpointStatus
+		}
+		return m, nil
+
+	case statusTickMsg:
+		return m, tea.Batch(m.fetchStatuses(), m.statusTick())
+
+	case townTickMsg:
+		if m.view.isTown() && !m.loading {
+			return m, tea.Batch(m.fetchTab(), m.townTick())
+		}
+		return m, m.townTick()
+
 	case errMsg:
 		m.loading = false
 		m.err = msg
@@ -218,6 +667,125 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateStream applies a daemon event: changed issues are highlighted and
+// the board and open issue are refreshed in place.
+func (m Model) updateStream(event StreamEvent) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{m.waitForEvent}
+
+	switch event.Type {
+	case EventConnected:
+		m.live = true
+		if m.streamErr != nil {
+			// Catch up on changes missed while disconnected
+			cmds = append(cmds, m.queueRefresh())
+		}
+		m.streamErr = nil
+
+	case EventDisconnected:
+		m.live = false
+		m.streamErr = event.Err
+		m.retry = event.Retry
+
+	default:
+		if event.IssueID == "" {
+			break
+		}
+		m.changed[event.IssueID] = time.Now()
+		cmds = append(cmds, m.queueRefresh(), tea.Tick(highlightDuration, func(time.Time) tea.Msg {
+			return highlightMsg{}
+		}))
+		if m.view == ViewIssue && m.issue != nil && m.issue.ID == event.IssueID &&
+			event.Type != string(model.EventTypeIssueDeleted) {
+			cmds = append(cmds, m.refreshIssue(event.IssueID))
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// queueRefresh schedules a board refresh unless one is already pending.
+func (m *Model) queueRefresh() tea.Cmd {
+	if m.refreshQueued {
+		return nil
+	}
+	m.refreshQueued = true
+	return tea.Tick(refreshDelay, func(time.Time) tea.Msg { return refreshMsg{} })
+}
+
+// selectedID returns the ID of the selected card, or "".
+func (m Model) selectedID() string {
+	cols := m.columns()
+	if m.cursor >= len(cols) {
+		return ""
+	}
+	col := cols[m.cursor]
+	if m.issueCur >= len(col.Issues) {
+		return ""
+	}
+	return col.Issues[m.issueCur].ID
+}
+
+// reselect moves the cursor to the card with the given ID after the board
+// or its filter changes, keeping it in range if the card is gone.
+func (m *Model) reselect(id string) {
+	cols := m.columns()
+	defer m.scrollToCursor()
+	if id != "" {
+		for i, col := range cols {
+			for j, issue := range col.Issues {
+				if issue.ID == id {
+					m.cursor, m.issueCur = i, j
+					return
+				}
+			}
+		}
+	}
+
+	m.cursor = min(m.cursor, max(len(cols)-1, 0))
+	if m.cursor < len(cols) {
+		m.issueCur = min(m.issueCur, max(len(cols[m.cursor].Issues)-1, 0))
+	}
+}
+
+// updateSearch handles keys while the search prompt is open. The query is
+// checked locally so syntax errors can be pointed at before it is sent.
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searching = false
+		m.queryErr = nil
+		m.search.Blur()
+		f := m.filter
+		f.text = ""
+		return m.refilter(f)
+
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.search.Value())
+		if _, err := query.Parse(value); err != nil {
+			m.queryErr, _ = err.(*query.Error)
+			return m, nil
+		}
+		m.searching = false
+		m.queryErr = nil
+		m.search.Blur()
+		m.filter.text = ""
+		m.query = value
+		m.cursor = 0
+		m.issueCur = 0
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.fetchBoard)
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+
+	// Narrow the loaded board as the user types
+	selected := m.selectedID()
+	m.filter.text = m.search.Value()
+	m.reselect(selected)
+	return m, cmd
+}
+
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
@@ -267,15 +835,18 @@ var (
 
 	labelStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("245"))
+
+	spinnerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205"))
 )
 
 // View renders the model.
 func (m Model) View() string {
 	if m.err != nil {
-		return m.viewError()
+		return m.viewStatusBar() + m.viewError()
 	}
 
-	if m.loading && m.board == nil {
+	if m.loading && m.board == nil && !m.view.isTown() {
 		return m.viewLoading()
 	}
 
@@ -285,10 +856,24 @@ func (m Model) View() string {
 		content = m.viewBoard()
 	case ViewIssue:
 		content = m.viewIssue()
+	case ViewTown:
+		content = m.viewTown()
+	case ViewConvoys:
+		content = m.viewConvoys()
+	case ViewMolecules:
+		content = m.viewMolecules()
+	case ViewMolecule:
+		content = m.viewMolecule()
+	case ViewMail:
+		content = m.viewMail()
+	case ViewMessage:
+		content = m.viewMessage()
+	case ViewGraph:
+		content = m.viewGraph()
 	}
 
 	helpView := m.help.View(m.keys)
-	return content + "\n\n" + helpView
+	return m.viewTabs() + "\n" + m.viewStatusBar() + content + "\n\n" + helpView
 }
 
 func (m Model) viewLoading() string {
@@ -296,9 +881,14 @@ func (m Model) viewLoading() string {
 }
 
 func (m Model) viewError() string {
-	return fmt.Sprintf("\n  %s\n\n  %s\n\n  Press 'r' to retry or 'q' to quit.\n",
-		errorStyle.Render("Error connecting to daemon:"),
-		m.err.Error())
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n  Press '%s' to retry or '%s' to quit.\n",
+		errorStyle.Render("Error fetching data:"),
+		m.err.Error(), m.keys.Refresh.Help().Key, m.keys.Quit.Help().Key) + m.switchHint()
+}
+
+// backHint tells the user how to leave a detail view.
+func (m Model) backHint() string {
+	return labelStyle.Render("< Press "+m.keys.Back.Help().Key+" to go back") + "\n\n"
 }
 
 func (m Model) viewBoard() string {
@@ -310,14 +900,40 @@ func (m Model) viewBoard() string {
 
 	// Title
 	title := titleStyle.Render("Gastown Viewer Intent")
+	if name := m.viewName(); name != "" {
+		title += " " + labelStyle.Render("view: "+name)
+	}
+	if m.query != "" && !m.searching {
+		title += " " + labelStyle.Render("query: "+m.query)
+	}
 	if m.loading {
 		title += " " + m.spinner.View()
 	}
-	b.WriteString(title + "\n\n")
+	title += " " + m.liveStatus()
+	b.WriteString(title + "\n")
+	if desc := m.filter.describe(m.board.Columns); desc != "" {
+		b.WriteString(labelStyle.Render(desc))
+	}
+	b.WriteString("\n")
+
+	if m.searching {
+		b.WriteString(m.search.View() + "\n")
+		if m.queryErr != nil {
+			// Point at the error below the input, past the prompt
+			indent := strings.Repeat(" ", lipgloss.Width(m.search.Prompt)+m.queryErr.Offset)
+			marker := strings.Repeat("^", max(m.queryErr.Length, 1))
+			b.WriteString(errorStyle.Render(indent+marker+" "+m.queryErr.Message) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if m.jumping {
+		b.WriteString(m.jump.View() + "\n\n")
+	}
+	b.WriteString(m.viewPrompt())
 
 	// Columns
 	var columns []string
-	for i, col := range m.board.Columns {
+	for i, col := range m.columns() {
 		colStyle := columnStyle
 		if i == m.cursor {
 			colStyle = selectedColumnStyle
@@ -338,21 +954,47 @@ func (m Model) viewBoard() string {
 			headerStyle = statusPending
 		}
 
-		header := headerStyle.Render(fmt.Sprintf("%s (%d)", col.Label, col.Count))
+		count := fmt.Sprintf("%d", col.Count)
+		if col.WIPLimit > 0 {
+			count = fmt.Sprintf("%d/%d", col.Count, col.WIPLimit)
+		}
+		if m.filter.active() {
+			count = fmt.Sprintf("%d of %s", len(col.Issues), count)
+		}
+		header := headerStyle.Render(fmt.Sprintf("%s (%s)", col.Label, count))
+		if col.OverLimit {
+			header = statusBlocked.Render(fmt.Sprintf("%s (%s) !", col.Label, count))
+		}
 
-		// Issues
+		// Issues, scrolled to the column's window
+		top := min(m.top[columnKey(col)], len(col.Issues))
+		end := visibleEnd(col.Issues, top, m.boardLines())
 		var issues []string
-		for j, issue := range col.Issues {
+		if top > 0 {
+			issues = append(issues, labelStyle.Render(fmt.Sprintf("↑ %d more", top)))
+		}
+		for j := top; j < end; j++ {
+			issue := col.Issues[j]
 			style := issueStyle
 			if i == m.cursor && j == m.issueCur {
 				style = selectedIssueStyle
 			}
+			if at, ok := m.changed[issue.ID]; ok && time.Since(at) < highlightDuration {
+				style = highlight(style)
+			}
 			// Truncate title
 			title := issue.Title
 			if len(title) > 20 {
 				title = title[:17] + "..."
 			}
-			issues = append(issues, style.Render(title))
+			card := style.Render(title)
+			if issue.Agent != nil {
+				card += "\n  " + agentBadge(*issue.Agent)
+			}
+			issues = append(issues, card)
+		}
+		if end < len(col.Issues) {
+			issues = append(issues, labelStyle.Render(fmt.Sprintf("↓ %d more", len(col.Issues)-end)))
 		}
 
 		content := header + "\n" + strings.Join(issues, "\n")
@@ -372,7 +1014,8 @@ func (m Model) viewIssue() string {
 	var b strings.Builder
 
 	// Back navigation hint
-	b.WriteString(labelStyle.Render("< Press ESC to go back") + "\n\n")
+	b.WriteString(m.backHint())
+	b.WriteString(m.viewPrompt())
 
 	// Title
 	b.WriteString(titleStyle.Render(m.issue.Title) + "\n")
@@ -399,19 +1042,47 @@ func (m Model) viewIssue() string {
 	// Description
 	if m.issue.Description != "" {
 		b.WriteString(labelStyle.Render("Description:\n"))
-		// Wrap description
-		desc := m.issue.Description
-		if len(desc) > 500 {
-			desc = desc[:497] + "..."
-		}
-		b.WriteString(desc + "\n\n")
+		b.WriteString(renderMarkdown(m.issue.Description, sameRigLinker(m.issue.ID)) + "\n\n")
 	}
 
 	// Done when
-	if len(m.issue.DoneWhen) > 0 {
-		b.WriteString(labelStyle.Render("Done when:\n"))
-		for _, item := range m.issue.DoneWhen {
-			b.WriteString("  - " + item + "\n")
+	if len(m.issue.Criteria) > 0 {
+		header := "Done when:"
+		if p := m.issue.CriteriaProgress; p != nil {
+			header = fmt.Sprintf("Done when: %d/%d", p.Checked, p.Total)
+		}
+		b.WriteString(labelStyle.Render(header + "\n"))
+		for _, item := range m.issue.Criteria {
+			marker := "-"
+			if item.Checkbox {
+				marker = "[ ]"
+				if item.Checked {
+					marker = "[x]"
+				}
+			}
+			b.WriteString("  " + marker + " " + item.Text + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Gas Town
+	if len(m.issue.Agents) > 0 {
+		b.WriteString(labelStyle.Render("Worked on by:\n"))
+		for _, agent := range m.issue.Agents {
+			line := fmt.Sprintf("  %s %s", agentBadge(agent), labelStyle.Render(agent.Address+" via "+agent.Via))
+			if agent.Via == model.AgentViaMolecule && agent.Molecule != "" && agent.Molecule != m.issue.ID {
+				line += labelStyle.Render(" " + agent.Molecule)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(m.issue.Convoys) > 0 {
+		b.WriteString(labelStyle.Render("Convoys:\n"))
+		for _, convoy := range m.issue.Convoys {
+			b.WriteString(fmt.Sprintf("  - %s (%s) %d/%d %s\n",
+				convoy.Title, convoy.ID, convoy.Progress, convoy.Total, labelStyle.Render(convoy.Status)))
 		}
 		b.WriteString("\n")
 	}
@@ -435,3 +1106,19 @@ func (m Model) viewIssue() string {
 
 	return detailStyle.Width(m.width - 4).Render(b.String())
 }
+
+// liveStatus describes the state of the event stream.
+func (m Model) liveStatus() string {
+	switch {
+	case m.live:
+		return statusDone.Render("● live")
+	case m.streamErr != nil:
+		return labelStyle.Render(fmt.Sprintf("○ offline, retrying in %s", m.retry.Round(time.Second)))
+	}
+	return labelStyle.Render("○ connecting")
+}
+
+// agentBadge renders an agent's name colored by its status.
+func agentBadge(agent model.AgentRef) string {
+	return agentStatusStyle(agent.Status).Render("@" + agent.Name + " " + agent.Status)
+}
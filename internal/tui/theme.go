@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the set of colors the TUI draws with. Colors are ANSI 256-color
+// numbers ("205") or hex values ("#d7005f"); empty fields keep the color of
+// the theme they are applied over.
+type Theme struct {
+	Accent   string `json:"accent,omitempty"`    // titles, selection and the active tab
+	OnAccent string `json:"on_accent,omitempty"` // text on the active tab
+	Text     string `json:"text,omitempty"`      // card titles
+	Muted    string `json:"muted,omitempty"`     // labels and inactive tabs
+	Border   string `json:"border,omitempty"`
+	Changed  string `json:"changed,omitempty"` // background of recently changed cards
+	Heading  string `json:"heading,omitempty"` // description headings and links
+	Code     string `json:"code,omitempty"`
+
+	// Status palette, also used for agent, convoy and molecule states
+	Pending    string `json:"pending,omitempty"`
+	InProgress string `json:"in_progress,omitempty"`
+	Done       string `json:"done,omitempty"`
+	Blocked    string `json:"blocked,omitempty"`
+}
+
+// themes are the built-in themes. dark is the default.
+var themes = map[string]Theme{
+	"dark": {
+		Accent: "205", OnAccent: "230", Text: "252", Muted: "245", Border: "240",
+		Changed: "58", Heading: "39", Code: "180",
+		Pending: "245", InProgress: "214", Done: "42", Blocked: "196",
+	},
+	"light": {
+		Accent: "161", OnAccent: "231", Text: "235", Muted: "242", Border: "248",
+		Changed: "229", Heading: "25", Code: "94",
+		Pending: "242", InProgress: "166", Done: "28", Blocked: "160",
+	},
+}
+
+// palettes are status palettes applied over a theme. colorblind uses the
+// Okabe-Ito colors, which stay distinct under the common color vision
+// deficiencies.
+var palettes = map[string]Theme{
+	"default": {},
+	"colorblind": {
+		Pending: "#999999", InProgress: "#E69F00", Done: "#0072B2", Blocked: "#D55E00",
+	},
+}
+
+// over returns t with its empty colors taken from base.
+func (t Theme) over(base Theme) Theme {
+	for _, f := range []struct{ dst, src *string }{
+		{&base.Accent, &t.Accent}, {&base.OnAccent, &t.OnAccent}, {&base.Text, &t.Text},
+		{&base.Muted, &t.Muted}, {&base.Border, &t.Border}, {&base.Changed, &t.Changed},
+		{&base.Heading, &t.Heading}, {&base.Code, &t.Code}, {&base.Pending, &t.Pending},
+		{&base.InProgress, &t.InProgress}, {&base.Done, &t.Done}, {&base.Blocked, &t.Blocked},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	return base
+}
+
+// noColor reports whether the user asked for no color (https://no-color.org).
+// lipgloss already drops colors then; the TUI also swaps color-only cues
+// for text attributes.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// changedColor is the background of recently changed cards.
+var changedColor = lipgloss.Color(themes["dark"].Changed)
+
+// highlight marks a recently changed card.
+func highlight(style lipgloss.Style) lipgloss.Style {
+	if noColor() {
+		return style.Underline(true)
+	}
+	return style.Background(changedColor)
+}
+
+// applyTheme recolors the package's styles. Styles are shared by every
+// Model, so this is done once before the program starts.
+func applyTheme(t Theme) {
+	c := func(s string) lipgloss.Color { return lipgloss.Color(s) }
+
+	titleStyle = titleStyle.Foreground(c(t.Accent))
+	columnStyle = columnStyle.BorderForeground(c(t.Border))
+	selectedColumnStyle = selectedColumnStyle.BorderForeground(c(t.Accent))
+	issueStyle = issueStyle.Foreground(c(t.Text))
+	selectedIssueStyle = selectedIssueStyle.Foreground(c(t.Accent))
+	statusPending = statusPending.Foreground(c(t.Pending))
+	statusInProgress = statusInProgress.Foreground(c(t.InProgress))
+	statusDone = statusDone.Foreground(c(t.Done))
+	statusBlocked = statusBlocked.Foreground(c(t.Blocked))
+	errorStyle = errorStyle.Foreground(c(t.Blocked))
+	detailStyle = detailStyle.BorderForeground(c(t.Border))
+	labelStyle = labelStyle.Foreground(c(t.Muted))
+	spinnerStyle = spinnerStyle.Foreground(c(t.Accent))
+	changedColor = c(t.Changed)
+
+	tabStyle = tabStyle.Foreground(c(t.Muted))
+	activeTabStyle = activeTabStyle.Foreground(c(t.OnAccent)).Background(c(t.Accent)).
+		Underline(noColor())
+
+	mdHeadingStyle = mdHeadingStyle.Foreground(c(t.Heading))
+	mdCodeStyle = mdCodeStyle.Foreground(c(t.Code))
+	mdCodeBlockStyle = mdCodeBlockStyle.Foreground(c(t.Code)).BorderForeground(c(t.Border))
+	mdQuoteStyle = mdQuoteStyle.Foreground(c(t.Muted))
+	mdLinkStyle = mdLinkStyle.Foreground(c(t.Heading))
+	mdRefStyle = mdRefStyle.Foreground(c(t.Accent))
+}
@@ -0,0 +1,588 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+)
+
+// tabs are the top-level views, in tab order.
+var tabs = []struct {
+	view  View
+	label string
+}{
+	{ViewBoard, "Board"},
+	{ViewTown, "Town"},
+	{ViewConvoys, "Convoys"},
+	{ViewMolecules, "Molecules"},
+	{ViewMail, "Mail"},
+}
+
+// defaultRefresh is how often the Gas Town tabs and the endpoint status
+// bar poll, since the daemon only streams issue events.
+const defaultRefresh = 15 * time.Second
+
+// Messages
+type townMsg *gastown.Town
+type convoysMsg []gastown.Convoy
+type moleculesMsg []gastown.Molecule
+type mailMsg struct {
+	address  string
+	messages []gastown.Message
+}
+type townTickMsg struct{}
+
+func (m Model) fetchTown() tea.Msg {
+	town, err := m.client.Town()
+	if err != nil {
+		return m.tag(errMsg(err))
+	}
+	return m.tag(townMsg(town))
+}
+
+func (m Model) fetchConvoys() tea.Msg {
+	convoys, err := m.client.Convoys()
+	if err != nil {
+		return m.tag(errMsg(err))
+	}
+	return m.tag(convoysMsg(convoys))
+}
+
+func (m Model) fetchMolecules() tea.Msg {
+	molecules, err := m.client.Molecules()
+	if err != nil {
+		return m.tag(errMsg(err))
+	}
+	return m.tag(moleculesMsg(molecules))
+}
+
+func (m Model) fetchMail(address string) tea.Cmd {
+	return func() tea.Msg {
+		messages, err := m.client.Mail(address)
+		if err != nil {
+			return m.tag(errMsg(err))
+		}
+		return m.tag(mailMsg{address: address, messages: messages})
+	}
+}
+
+func (m Model) townTick() tea.Cmd {
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg { return townTickMsg{} })
+}
+
+// tab returns the top-level view a view belongs to.
+func (v View) tab() View {
+	switch v {
+	case ViewIssue:
+		return ViewBoard
+	case ViewMolecule:
+		return ViewMolecules
+	case ViewMessage:
+		return ViewMail
+	}
+	return v
+}
+
+// isTown reports whether v shows Gas Town data.
+func (v View) isTown() bool {
+	return v.tab() != ViewBoard
+}
+
+// switchTab moves by delta tabs, wrapping around, and loads the new tab.
+func (m Model) switchTab(delta int) (tea.Model, tea.Cmd) {
+	current := 0
+	for i, t := range tabs {
+		if t.view == m.view.tab() {
+			current = i
+		}
+	}
+	next := (current + delta + len(tabs)) % len(tabs)
+	m.view = tabs[next].view
+	m.err = nil
+	if m.view == ViewBoard {
+		return m, nil
+	}
+	m.loading = true
+	return m, tea.Batch(m.spinner.Tick, m.fetchTab())
+}
+
+// fetchTab loads the data shown by the current Gas Town view.
+func (m Model) fetchTab() tea.Cmd {
+	switch m.view {
+	case ViewTown:
+		return m.fetchTown
+	case ViewConvoys:
+		return m.fetchConvoys
+	case ViewMolecules, ViewMolecule:
+		return m.fetchMolecules
+	case ViewMail, ViewMessage:
+		if m.mailAddr != "" {
+			return m.fetchMail(m.mailAddr)
+		}
+		return m.fetchTown
+	}
+	return nil
+}
+
+// rowCount returns the number of selectable rows in the current view.
+func (m Model) rowCount() int {
+	switch m.view {
+	case ViewTown:
+		return len(m.townAgents())
+	case ViewConvoys:
+		return len(m.convoys)
+	case ViewMolecules:
+		return len(m.molecules)
+	case ViewMolecule:
+		if m.molecule != nil {
+			return len(m.molecule.Steps)
+		}
+	case ViewMail:
+		if m.mailAddr == "" {
+			return len(m.townAgents())
+		}
+		return len(m.mail)
+	}
+	return 0
+}
+
+// updateTownKey handles navigation in the Gas Town views.
+func (m Model) updateTownKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	row := m.rows[m.view]
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if row > 0 {
+			m.rows[m.view] = row - 1
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if row < m.rowCount()-1 {
+			m.rows[m.view] = row + 1
+		}
+
+	case key.Matches(msg, m.keys.Back):
+		switch {
+		case m.view == ViewMolecule:
+			m.view = ViewMolecules
+		case m.view == ViewMessage:
+			m.view = ViewMail
+		case m.view == ViewMail && m.mailAddr != "":
+			m.mailAddr = ""
+			m.mail = nil
+		}
+
+	case key.Matches(msg, m.keys.Enter):
+		return m.openRow(row)
+	}
+
+	return m, nil
+}
+
+// openRow opens the selected row of the current view.
+func (m Model) openRow(row int) (tea.Model, tea.Cmd) {
+	switch m.view {
+	case ViewTown, ViewMail:
+		if m.view == ViewMail && m.mailAddr != "" {
+			if row < len(m.mail) {
+				msg := m.mail[row]
+				m.message = &msg
+				m.view = ViewMessage
+			}
+			return m, nil
+		}
+		agents := m.townAgents()
+		if row >= len(agents) {
+			return m, nil
+		}
+		// Selecting an agent opens its inbox
+		m.view = ViewMail
+		m.mailAddr = agents[row].Address()
+		m.mail = nil
+		m.rows[ViewMail] = 0
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.fetchMail(m.mailAddr))
+
+	case ViewConvoys:
+		if row < len(m.convoys) {
+			id := m.convoys[row].ID
+			m.expanded[id] = !m.expanded[id]
+		}
+
+	case ViewMolecules:
+		if row < len(m.molecules) {
+			mol := m.molecules[row]
+			m.molecule = &mol
+			m.view = ViewMolecule
+			m.rows[ViewMolecule] = mol.CurrentStep
+		}
+	}
+	return m, nil
+}
+
+// updateTownData stores fetched Gas Town data.
+func (m Model) updateTownData(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	m.err = nil
+
+	switch msg := msg.(type) {
+	case townMsg:
+		m.town = msg
+	case convoysMsg:
+		m.convoys = msg
+	case moleculesMsg:
+		m.molecules = msg
+		if m.molecule != nil {
+			for i := range m.molecules {
+				if m.molecules[i].ID == m.molecule.ID {
+					mol := m.molecules[i]
+					m.molecule = &mol
+				}
+			}
+		}
+	case mailMsg:
+		if msg.address == m.mailAddr {
+			m.mail = msg.messages
+		}
+	}
+
+	// Keep cursors in range as lists shrink
+	for view, row := range m.rows {
+		saved := m.view
+		m.view = view
+		if n := m.rowCount(); row >= n {
+			m.rows[view] = max(n-1, 0)
+		}
+		m.view = saved
+	}
+	return m, nil
+}
+
+// townAgents lists the town's agents in tree order.
+func (m Model) townAgents() []gastown.Agent {
+	if m.town == nil {
+		return nil
+	}
+	var agents []gastown.Agent
+	if m.town.Mayor != nil {
+		agents = append(agents, *m.town.Mayor)
+	}
+	if m.town.Deacon != nil {
+		agents = append(agents, *m.town.Deacon)
+	}
+	for _, rig := range m.town.Rigs {
+		agents = append(agents, rigAgents(rig)...)
+	}
+	return agents
+}
+
+func rigAgents(rig gastown.Rig) []gastown.Agent {
+	var agents []gastown.Agent
+	if rig.Witness != nil {
+		agents = append(agents, *rig.Witness)
+	}
+	if rig.Refinery != nil {
+		agents = append(agents, *rig.Refinery)
+	}
+	agents = append(agents, rig.Polecats...)
+	return append(agents, rig.Crew...)
+}
+
+var (
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("245"))
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).
+			Foreground(lipgloss.Color("230")).Background(lipgloss.Color("205"))
+)
+
+// viewTabs renders the tab bar.
+func (m Model) viewTabs() string {
+	var parts []string
+	for _, t := range tabs {
+		if t.view == m.view.tab() {
+			parts = append(parts, activeTabStyle.Render(t.label))
+		} else {
+			parts = append(parts, tabStyle.Render(t.label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// agentStatusStyle colors an agent status.
+func agentStatusStyle(status string) lipgloss.Style {
+	switch gastown.AgentStatus(status) {
+	case gastown.StatusActive:
+		return statusDone
+	case gastown.StatusIdle:
+		return statusInProgress
+	case gastown.StatusStuck:
+		return statusBlocked
+	}
+	return statusPending
+}
+
+// cursorLine renders a list row, marking the selected one.
+func cursorLine(selected bool, text string) string {
+	if selected {
+		return selectedIssueStyle.Render("> ") + text
+	}
+	return "  " + text
+}
+
+func (m Model) viewTown() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Town") + m.loadingMark() + "\n")
+	if m.town == nil {
+		return b.String() + labelStyle.Render("No town data.")
+	}
+	if m.town.Name != "" {
+		b.WriteString(labelStyle.Render(m.town.Name+"  "+m.town.Root) + "\n\n")
+	}
+
+	row := 0
+	agentLine := func(prefix string, a gastown.Agent) {
+		name := a.Name
+		if a.Role != gastown.RoleCrew && a.Role != gastown.RolePolecat {
+			name = string(a.Role)
+		}
+		text := prefix + name + " " + agentStatusStyle(string(a.Status)).Render("● "+string(a.Status))
+		var work []string
+		if a.HookBead != "" {
+			work = append(work, "hook "+a.HookBead)
+		}
+		if a.Molecule != "" {
+			work = append(work, "molecule "+a.Molecule)
+		}
+		if len(work) > 0 {
+			text += labelStyle.Render("  " + strings.Join(work, ", "))
+		}
+		b.WriteString(cursorLine(row == m.rows[ViewTown], text) + "\n")
+		row++
+	}
+
+	if m.town.Mayor != nil {
+		agentLine("", *m.town.Mayor)
+	}
+	if m.town.Deacon != nil {
+		agentLine("", *m.town.Deacon)
+	}
+	for _, rig := range m.town.Rigs {
+		b.WriteString("\n  " + lipgloss.NewStyle().Bold(true).Render(rig.Name) + "\n")
+		agents := rigAgents(rig)
+		for i, a := range agents {
+			branch := "├─ "
+			if i == len(agents)-1 {
+				branch = "└─ "
+			}
+			agentLine(branch, a)
+		}
+	}
+
+	b.WriteString("\n" + labelStyle.Render("enter: open mailbox"))
+	return b.String()
+}
+
+func (m Model) viewConvoys() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Convoys") + m.loadingMark() + "\n")
+	if len(m.convoys) == 0 {
+		return b.String() + labelStyle.Render("No active convoys.")
+	}
+
+	for i, c := range m.convoys {
+		done := c.Completed
+		if done == 0 {
+			done = c.Progress
+		}
+		line := fmt.Sprintf("%s %3d/%-3d %s %s",
+			progressBar(done, c.Total, 20), done, c.Total, c.Title, labelStyle.Render(c.ID+" "+string(c.Status)))
+		if c.Blocked > 0 {
+			line += statusBlocked.Render(fmt.Sprintf(" %d blocked", c.Blocked))
+		}
+		b.WriteString(cursorLine(i == m.rows[ViewConvoys], line) + "\n")
+		if m.expanded[c.ID] {
+			for _, id := range c.Issues {
+				b.WriteString("      " + labelStyle.Render(id) + "\n")
+			}
+			if len(c.Agents) > 0 {
+				b.WriteString("      " + labelStyle.Render("agents: "+strings.Join(c.Agents, ", ")) + "\n")
+			}
+		}
+	}
+
+	b.WriteString("\n" + labelStyle.Render("enter: show issues"))
+	return b.String()
+}
+
+// progressBar renders done/total as a bar of the given width.
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*width/total, width)
+	}
+	return statusDone.Render(strings.Repeat("█", filled)) + labelStyle.Render(strings.Repeat("░", width-filled))
+}
+
+// moleculeStatusStyle colors a molecule or step status.
+func moleculeStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case "in_progress":
+		return statusInProgress
+	case "complete", "completed", "done":
+		return statusDone
+	case "blocked", "failed":
+		return statusBlocked
+	}
+	return statusPending
+}
+
+func (m Model) viewMolecules() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Molecules") + m.loadingMark() + "\n")
+	if len(m.molecules) == 0 {
+		return b.String() + labelStyle.Render("No active molecules.")
+	}
+
+	for i, mol := range m.molecules {
+		line := fmt.Sprintf("%s %3d/%-3d %s %s",
+			progressBar(mol.Progress, mol.Total, 10), mol.Progress, mol.Total, mol.Title,
+			moleculeStatusStyle(string(mol.Status)).Render(string(mol.Status)))
+		owner := mol.ID
+		if mol.Agent != "" {
+			owner += " " + mol.Rig + "/" + mol.Agent
+		}
+		line += labelStyle.Render("  " + owner)
+		b.WriteString(cursorLine(i == m.rows[ViewMolecules], line) + "\n")
+	}
+
+	b.WriteString("\n" + labelStyle.Render("enter: view steps"))
+	return b.String()
+}
+
+// stepMarkers are the glyphs for molecule step statuses.
+var stepMarkers = map[string]string{
+	"complete":    "✓",
+	"completed":   "✓",
+	"done":        "✓",
+	"in_progress": "▶",
+	"blocked":     "■",
+	"failed":      "✗",
+}
+
+func (m Model) viewMolecule() string {
+	mol := m.molecule
+	if mol == nil {
+		return m.viewLoading()
+	}
+
+	var b strings.Builder
+	b.WriteString(m.backHint())
+	b.WriteString(titleStyle.Render(mol.Title) + m.loadingMark() + "\n")
+	meta := fmt.Sprintf("%s  %d/%d steps", mol.ID, mol.Progress, mol.Total)
+	if mol.Formula != "" {
+		meta += "  formula " + mol.Formula
+	}
+	if mol.Agent != "" {
+		meta += "  agent " + mol.Rig + "/" + mol.Agent
+	}
+	if mol.Issue != "" {
+		meta += "  issue " + mol.Issue
+	}
+	b.WriteString(moleculeStatusStyle(string(mol.Status)).Render(string(mol.Status)) + "  " + labelStyle.Render(meta) + "\n\n")
+
+	for i, step := range mol.Steps {
+		marker, ok := stepMarkers[step.Status]
+		if !ok {
+			marker = "·"
+		}
+		line := moleculeStatusStyle(step.Status).Render(marker) + " " + step.ID
+		if step.Description != "" {
+			line += "  " + step.Description
+		}
+		var details []string
+		if len(step.Needs) > 0 {
+			details = append(details, "needs "+strings.Join(step.Needs, ", "))
+		}
+		if step.StartedAt != nil {
+			end := time.Now()
+			if step.CompletedAt != nil {
+				end = *step.CompletedAt
+			}
+			details = append(details, end.Sub(*step.StartedAt).Round(time.Second).String())
+		}
+		if len(details) > 0 {
+			line += labelStyle.Render("  (" + strings.Join(details, "; ") + ")")
+		}
+		if i == mol.CurrentStep && mol.Status == gastown.MolStatusInProgress {
+			line += statusInProgress.Render("  ← current")
+		}
+		b.WriteString(cursorLine(i == m.rows[ViewMolecule], line) + "\n")
+	}
+
+	return detailStyle.Width(m.width - 4).Render(b.String())
+}
+
+func (m Model) viewMail() string {
+	var b strings.Builder
+
+	if m.mailAddr == "" {
+		b.WriteString(titleStyle.Render("Mail") + m.loadingMark() + "\n")
+		agents := m.townAgents()
+		if len(agents) == 0 {
+			return b.String() + labelStyle.Render("No agents.")
+		}
+		for i, a := range agents {
+			b.WriteString(cursorLine(i == m.rows[ViewMail], a.Address()) + "\n")
+		}
+		b.WriteString("\n" + labelStyle.Render("enter: open mailbox"))
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render("Mail: "+m.mailAddr) + m.loadingMark() + "\n")
+	if len(m.mail) == 0 {
+		b.WriteString(labelStyle.Render("Inbox empty."))
+	}
+	for i, msg := range m.mail {
+		mark := " "
+		if !msg.Read {
+			mark = statusInProgress.Render("●")
+		}
+		line := fmt.Sprintf("%s %s  %s %s", mark, msg.Timestamp.Format("Jan 02 15:04"),
+			msg.Subject, labelStyle.Render("from "+msg.From))
+		b.WriteString(cursorLine(i == m.rows[ViewMail], line) + "\n")
+	}
+	b.WriteString("\n" + labelStyle.Render("enter: read  esc: all mailboxes"))
+	return b.String()
+}
+
+func (m Model) viewMessage() string {
+	msg := m.message
+	if msg == nil {
+		return m.viewMail()
+	}
+
+	var b strings.Builder
+	b.WriteString(m.backHint())
+	b.WriteString(titleStyle.Render(msg.Subject) + "\n")
+	b.WriteString(labelStyle.Render("From: ") + msg.From + "\n")
+	b.WriteString(labelStyle.Render("To:   ") + msg.To + "\n")
+	b.WriteString(labelStyle.Render("Date: ") + msg.Timestamp.Format("2006-01-02 15:04") + "\n")
+	if msg.Priority != "" || msg.Type != "" {
+		b.WriteString(labelStyle.Render(strings.TrimSpace(msg.Priority+" "+msg.Type)) + "\n")
+	}
+	b.WriteString("\n" + renderMarkdown(msg.Body, nil))
+
+	return detailStyle.Width(m.width - 4).Render(b.String())
+}
+
+// loadingMark returns the spinner while data is loading.
+func (m Model) loadingMark() string {
+	if m.loading {
+		return " " + m.spinner.View()
+	}
+	return ""
+}
@@ -0,0 +1,237 @@
+// Package client is a Go client for the gvid API.
+//
+//	c := client.New("http://localhost:7070")
+//	list, err := c.Issues(ctx, client.IssueQuery{Q: "status:blocked"})
+//	if errors.Is(err, client.ErrBeadsUnavailable) {
+//		// bd is missing or beads is not initialized
+//	}
+//
+// Errors from gvid are returned as *Error and match the kinds ErrNotFound,
+// ErrInvalid and so on with errors.Is. Idempotent requests are retried with
+// backoff when gvid cannot be reached; Subscribe streams live events.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Defaults for New.
+const (
+	DefaultTimeout   = 30 * time.Second
+	DefaultRetries   = 2
+	DefaultRetryWait = 250 * time.Millisecond
+)
+
+// Client calls the gvid API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	// streamClient has no timeout, since the event stream stays open.
+	streamClient *http.Client
+	retries      int
+	retryWait    time.Duration
+	userAgent    string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends requests through hc. Its timeout applies to every
+// request except the event stream.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+		stream := *hc
+		stream.Timeout = 0
+		c.streamClient = &stream
+	}
+}
+
+// WithTimeout sets the timeout of each request, DefaultTimeout by default.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// WithRetries sets how often a failed GET, PUT or DELETE is retried, and
+// the wait before the first retry, which doubles with each attempt.
+// Zero retries disables them; negative values are treated as zero.
+func WithRetries(n int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.retries, c.retryWait = max(n, 0), max(wait, 0)
+	}
+}
+
+// WithUserAgent sets the User-Agent header, so gvid's logs can tell bots
+// apart.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// New creates a client for the gvid daemon at baseURL, such as
+// "http://localhost:7070".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: DefaultTimeout},
+		streamClient: &http.Client{},
+		retries:      DefaultRetries,
+		retryWait:    DefaultRetryWait,
+		userAgent:    "gvid-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the daemon's address.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// do sends a request and returns the response if it succeeded. Idempotent
+// requests are retried after transport failures and gateway errors; an
+// error response from gvid itself is an answer, not a failure, and is
+// returned as an *Error.
+func (c *Client) do(ctx context.Context, method, path string, params url.Values, body any) (*http.Response, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	u := c.baseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	retries := 0
+	if method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete {
+		retries = c.retries
+	}
+	wait := c.retryWait
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, c.httpClient, method, u, data)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= retries || !retryable(err) {
+			return nil, err
+		}
+
+		// Up to 20% jitter keeps a fleet of bots from retrying in step
+		delay := wait + time.Duration(rand.Int64N(int64(wait)/5+1))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		wait *= 2
+	}
+}
+
+// send makes one attempt at a request.
+func (c *Client) send(ctx context.Context, hc *http.Client, method, u string, data []byte) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// retryable reports whether a failed request may succeed if repeated.
+func retryable(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			// A JSON body is gvid's own answer rather than a proxy's
+			return !json.Valid(apiErr.body)
+		}
+		return false
+	}
+	return errors.Is(err, ErrUnreachable)
+}
+
+// decodeError reads an error response.
+func decodeError(resp *http.Response) *Error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	var body struct {
+		Error   string         `json:"error"`
+		Code    string         `json:"code"`
+		Details map[string]any `json:"details"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Error == "" {
+		return &Error{StatusCode: resp.StatusCode, Message: "request failed: " + resp.Status, body: data}
+	}
+	return &Error{StatusCode: resp.StatusCode, Code: body.Code, Message: body.Error, Details: body.Details, body: data}
+}
+
+// getJSON fetches an API path and decodes the JSON response into v.
+func (c *Client) getJSON(ctx context.Context, path string, params url.Values, v any) error {
+	return c.sendJSON(ctx, http.MethodGet, path, params, nil, v)
+}
+
+// sendJSON sends body as JSON and decodes the response into v, if not nil.
+func (c *Client) sendJSON(ctx context.Context, method, path string, params url.Values, body, v any) error {
+	resp, err := c.do(ctx, method, path, params, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+// getBytes fetches an API path that returns something other than JSON,
+// such as a DOT graph or a CSV export.
+func (c *Client) getBytes(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestErrorKinds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/issues/gt-404":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"issue not found: gt-404","code":"ISSUE_NOT_FOUND"}`)
+		case "/api/v1/board":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"unknown field","code":"INVALID_QUERY","details":{"offset":3}}`)
+		case "/api/v1/views":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"Saved views are disabled.","code":"VIEWS_DISABLED"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	_, err := c.Issue(ctx, "gt-404")
+	var apiErr *Error
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) || apiErr.Code != CodeIssueNotFound {
+		t.Errorf("Issue: %v", err)
+	}
+	_, err = c.Board(ctx, IssueQuery{Q: "foo:bar"})
+	if !errors.Is(err, ErrInvalid) || errors.Is(err, ErrNotFound) {
+		t.Errorf("Board: %v", err)
+	}
+	if errors.As(err, &apiErr) && apiErr.Details["offset"] != float64(3) {
+		t.Errorf("details = %v", apiErr.Details)
+	}
+	if _, err = c.Views(ctx); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Views: %v", err)
+	}
+	if _, err = c.Rig(ctx, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Rig without a code: %v", err)
+	}
+	if _, err = New("http://127.0.0.1:1", WithRetries(0, 0)).Town(ctx); !errors.Is(err, ErrUnreachable) {
+		t.Errorf("unreachable: %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Path {
+		case "/api/v1/town/status":
+			if n < 3 {
+				http.Error(w, "bad gateway", http.StatusBadGateway)
+				return
+			}
+			fmt.Fprint(w, `{"healthy":true,"active_agents":2}`)
+		case "/api/v1/health":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"status":"error","beads_initialized":false,"error":"bd CLI not found"}`)
+		default:
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	c := New(srv.URL, WithRetries(2, time.Millisecond))
+	ctx := context.Background()
+
+	status, err := c.TownStatus(ctx)
+	if err != nil || !status.Healthy || calls.Load() != 3 {
+		t.Fatalf("TownStatus = %+v, %v after %d calls", status, err, calls.Load())
+	}
+
+	// gvid's own answer is not retried, and an unhealthy daemon is not an error
+	calls.Store(0)
+	health, err := c.Health(ctx)
+	if err != nil || health.Status != "error" || health.Error != "bd CLI not found" || calls.Load() != 1 {
+		t.Errorf("Health = %+v, %v after %d calls", health, err, calls.Load())
+	}
+
+	// A negative wait retries at once instead of panicking
+	calls.Store(0)
+	if _, err := New(srv.URL, WithRetries(1, -time.Second)).Rigs(ctx); err == nil || calls.Load() != 2 {
+		t.Errorf("Rigs: %v after %d calls", err, calls.Load())
+	}
+
+	// Changes are not retried
+	calls.Store(0)
+	title := "x"
+	if _, err := c.UpdateIssue(ctx, "gt-1", IssueUpdate{Title: &title}); err == nil || calls.Load() != 1 {
+		t.Errorf("UpdateIssue: %v after %d calls", err, calls.Load())
+	}
+}
+
+func TestMolecules(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Query().Get("include") != "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"Molecule archive is disabled.","code":"ARCHIVE_DISABLED"}`)
+			return
+		}
+		fmt.Fprint(w, `{"molecules":[{"id":"mol-1","status":"in_progress"}]}`)
+	}))
+	defer srv.Close()
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	molecules, err := c.Molecules(ctx, MoleculeQuery{Since: since})
+	if err != nil || len(molecules) != 1 || molecules[0].Status != MoleculeInProgress {
+		t.Fatalf("Molecules = %+v, %v", molecules, err)
+	}
+	if want := "since=2026-01-02T03%3A04%3A05Z"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+
+	_, err = c.Molecules(ctx, MoleculeQuery{Include: "completed", Until: since})
+	if want := "include=completed&until=2026-01-02T03%3A04%3A05Z"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if !errors.Is(err, ErrDisabled) {
+		t.Errorf("Molecules with include: %v", err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	long := strings.Repeat("x", 100<<10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: connected\ndata: {\"message\":\"hi\"}\n\n")
+		fmt.Fprint(w, "event: heartbeat\ndata: {}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"untyped\"}\n\n")
+		fmt.Fprint(w, "event: issue_updated\ndata: {\"id\":\"gt-1\",\"status\":\"done\"}\n\n")
+		fmt.Fprint(w, "event: issue_created\ndata: {\"id\":\"gt-2\",\ndata: \"title\":\"Two\"}\n\n")
+		fmt.Fprintf(w, "event: issue_created\ndata: {\"id\":\"gt-3\",\"title\":%q}\n\n", long)
+	}))
+	defer srv.Close()
+
+	events := func(opts ...SubscribeOption) []Event {
+		sub, err := New(srv.URL).Subscribe(context.Background(), opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sub.Close()
+		var got []Event
+		for sub.Next() {
+			got = append(got, sub.Event())
+		}
+		if !errors.Is(sub.Err(), ErrUnreachable) {
+			t.Errorf("Err = %v, want ErrUnreachable once the stream drops", sub.Err())
+		}
+		return got
+	}
+
+	var got []string
+	for _, e := range events() {
+		got = append(got, e.Type+" "+e.IssueID())
+	}
+	// The untyped event is dropped without leaking its data into the next
+	// one, and a line longer than 64 KB is read whole
+	want := []string{"connected ", "issue_updated gt-1", "issue_created gt-2", "issue_created gt-3"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+
+	all := events(WithHeartbeats())
+	if len(all) != 5 || all[1].Type != EventHeartbeat {
+		t.Errorf("events with heartbeats = %v", all)
+	}
+	// Data lines are joined with newlines, as the SSE spec requires
+	if data := string(all[3].Data); data != "{\"id\":\"gt-2\",\n\"title\":\"Two\"}" {
+		t.Errorf("multi-line data = %q", data)
+	}
+}
+
+// The re-exported enumerations are part of the public API: their values
+// must not change with gvid's internal packages.
+func TestWireValues(t *testing.T) {
+	values := [][2]string{
+		{string(StatusBlocked), "blocked"},
+		{string(StatusInProgress), "in_progress"},
+		{string(PriorityHigh), "high"},
+		{string(PriorityBacklog), "backlog"},
+		{string(EdgeTypeBlocks), "blocks"},
+		{string(EdgeTypeParent), "parent"},
+		{string(GroupByAssignee), "assignee"},
+		{string(RolePolecat), "polecat"},
+		{string(AgentStuck), "stuck"},
+		{string(AgentOffline), "offline"},
+		{string(ConvoyBlocked), "blocked"},
+		{string(MoleculeFailed), "failed"},
+		{string(EdgeTypeConditional), "conditional_blocks"},
+	}
+	for _, v := range values {
+		if got, want := v[0], v[1]; got != want {
+			t.Errorf("constant = %q, want %q", got, want)
+		}
+	}
+}
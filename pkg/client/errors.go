@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes returned by gvid in ErrorResponse.Code.
+const (
+	CodeBDNotFound        = "BD_NOT_FOUND"
+	CodeBeadsNotInit      = "BEADS_NOT_INIT"
+	CodeBDError           = "BD_ERROR"
+	CodeParseError        = "PARSE_ERROR"
+	CodeIssueNotFound     = "ISSUE_NOT_FOUND"
+	CodeRigNotFound       = "RIG_NOT_FOUND"
+	CodeConvoyNotFound    = "CONVOY_NOT_FOUND"
+	CodeMoleculeNotFound  = "MOLECULE_NOT_FOUND"
+	CodeViewNotFound      = "VIEW_NOT_FOUND"
+	CodeViewExists        = "VIEW_EXISTS"
+	CodeInvalidParam      = "INVALID_PARAM"
+	CodeInvalidQuery      = "INVALID_QUERY"
+	CodeInvalidUpdate     = "INVALID_UPDATE"
+	CodeInvalidDependency = "INVALID_DEPENDENCY"
+	CodeInvalidView       = "INVALID_VIEW"
+	CodeReadOnly          = "READ_ONLY"
+	CodeForbiddenHost     = "FORBIDDEN_HOST"
+	CodeForbiddenOrigin   = "FORBIDDEN_ORIGIN"
+	CodeUnsupportedMedia  = "UNSUPPORTED_MEDIA_TYPE"
+	CodeViewsDisabled     = "VIEWS_DISABLED"
+	CodeHistoryDisabled   = "HISTORY_DISABLED"
+	CodeArchiveDisabled   = "ARCHIVE_DISABLED"
+	CodeGastownError      = "GASTOWN_ERROR"
+)
+
+// Error kinds, for use with errors.Is. An *Error matches the kind of its
+// code, so callers need not list every code.
+var (
+	// ErrNotFound: the issue, rig, convoy, molecule or view does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid: the request was rejected as malformed.
+	ErrInvalid = errors.New("invalid request")
+	// ErrConflict: a saved view with the name already exists.
+	ErrConflict = errors.New("conflict")
+	// ErrReadOnly: gvid runs without -allow-writes and rejected a change.
+	ErrReadOnly = errors.New("read-only")
+	// ErrDisabled: the feature needs gvid's data directory, which is off.
+	ErrDisabled = errors.New("disabled")
+	// ErrBeadsUnavailable: bd is not installed or beads is not initialized.
+	ErrBeadsUnavailable = errors.New("beads unavailable")
+	// ErrUnreachable: gvid could not be reached. It wraps the transport error.
+	ErrUnreachable = errors.New("gvid unreachable")
+)
+
+var codeKinds = map[string]error{
+	CodeBDNotFound:        ErrBeadsUnavailable,
+	CodeBeadsNotInit:      ErrBeadsUnavailable,
+	CodeIssueNotFound:     ErrNotFound,
+	CodeRigNotFound:       ErrNotFound,
+	CodeConvoyNotFound:    ErrNotFound,
+	CodeMoleculeNotFound:  ErrNotFound,
+	CodeViewNotFound:      ErrNotFound,
+	CodeViewExists:        ErrConflict,
+	CodeInvalidParam:      ErrInvalid,
+	CodeInvalidQuery:      ErrInvalid,
+	CodeInvalidUpdate:     ErrInvalid,
+	CodeInvalidDependency: ErrInvalid,
+	CodeInvalidView:       ErrInvalid,
+	CodeUnsupportedMedia:  ErrInvalid,
+	CodeReadOnly:          ErrReadOnly,
+	CodeViewsDisabled:     ErrDisabled,
+	CodeHistoryDisabled:   ErrDisabled,
+	CodeArchiveDisabled:   ErrDisabled,
+}
+
+// Error is an error response from gvid.
+type Error struct {
+	StatusCode int
+	Code       string // empty for responses without a code, such as a proxy's
+	Message    string
+	// Details carries extra information, such as the offset of a query
+	// syntax error.
+	Details map[string]any
+
+	body []byte
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+	}
+	return e.Message
+}
+
+// Is reports whether e is of the error kind target, such as ErrNotFound.
+// Responses without a code are classified by their HTTP status.
+func (e *Error) Is(target error) bool {
+	if kind, ok := codeKinds[e.Code]; ok {
+		return kind == target
+	}
+	if e.Code != "" {
+		return false
+	}
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusBadRequest:
+		return target == ErrInvalid
+	case http.StatusConflict:
+		return target == ErrConflict
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// Event types sent on the event stream.
+const (
+	EventConnected    = "connected" // first event of every stream
+	EventIssueCreated = "issue_created"
+	EventIssueUpdated = "issue_updated"
+	EventIssueDeleted = "issue_deleted"
+	EventHeartbeat    = "heartbeat"
+)
+
+// maxEventLine caps one line of the event stream. Issue payloads can
+// exceed bufio.Scanner's 64 KB default.
+const maxEventLine = 4 << 20
+
+// Event is one event from gvid's event stream.
+type Event struct {
+	Type string
+	Data json.RawMessage
+}
+
+// IssueID returns the ID of the issue an issue event is about, or "".
+func (e Event) IssueID() string {
+	var payload struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(e.Data, &payload) != nil {
+		return ""
+	}
+	return payload.ID
+}
+
+// Subscription is an open event stream. Read it like a bufio.Scanner:
+//
+//	sub, err := c.Subscribe(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer sub.Close()
+//	for sub.Next() {
+//		e := sub.Event()
+//		...
+//	}
+//	return sub.Err()
+type Subscription struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	event   Event
+	err     error
+	closed  atomic.Bool
+
+	heartbeats bool
+}
+
+// SubscribeOption configures a Subscription.
+type SubscribeOption func(*Subscription)
+
+// WithHeartbeats delivers the heartbeat events that keep an idle stream
+// open. They are skipped by default.
+func WithHeartbeats() SubscribeOption {
+	return func(s *Subscription) {
+		s.heartbeats = true
+	}
+}
+
+// Subscribe opens the event stream. The stream stays open until ctx is
+// done, Close is called or the connection drops; it is not retried or
+// reconnected, which is left to the caller.
+func (c *Client) Subscribe(ctx context.Context, opts ...SubscribeOption) (*Subscription, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/events", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	s := &Subscription{ctx: ctx, body: resp.Body, scanner: bufio.NewScanner(resp.Body)}
+	s.scanner.Buffer(make([]byte, 0, 64<<10), maxEventLine)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Next waits for the next event, reporting false once the stream has
+// ended.
+func (s *Subscription) Next() bool {
+	var eventType string
+	var data []string
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			// A multi-line payload is sent as consecutive data lines
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "":
+			// A blank line ends an event; lines of an untyped or skipped
+			// event must not leak into the next one
+			typ, payload := eventType, strings.Join(data, "\n")
+			eventType, data = "", nil
+			if typ == "" || typ == EventHeartbeat && !s.heartbeats {
+				continue
+			}
+			s.event = Event{Type: typ, Data: json.RawMessage(payload)}
+			if !json.Valid(s.event.Data) {
+				s.event.Data = json.RawMessage("null")
+			}
+			return true
+		}
+	}
+
+	switch {
+	case s.closed.Load():
+	case s.ctx.Err() != nil:
+		s.err = s.ctx.Err()
+	case s.scanner.Err() != nil:
+		s.err = fmt.Errorf("%w: %w", ErrUnreachable, s.scanner.Err())
+	default:
+		s.err = fmt.Errorf("%w: event stream closed", ErrUnreachable)
+	}
+	s.Close()
+	return false
+}
+
+// Event returns the event read by the last call to Next.
+func (s *Subscription) Event() Event {
+	return s.event
+}
+
+// Err returns why the stream ended: the context's error, or an error
+// matching ErrUnreachable if the connection dropped. It is nil if the
+// stream was ended by Close.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close closes the stream, ending a pending Next. It is safe to call
+// from another goroutine.
+func (s *Subscription) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+	return s.body.Close()
+}
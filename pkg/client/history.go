@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// HistoryRange selects a window of gvid's history. Zero times default to
+// the last 24 hours; a Step downsamples series to one sample per step.
+type HistoryRange struct {
+	Since time.Time
+	Until time.Time
+	Step  time.Duration
+}
+
+func (r HistoryRange) values() url.Values {
+	params := url.Values{}
+	setTime(params, "since", r.Since)
+	setTime(params, "until", r.Until)
+	if r.Step > 0 {
+		params.Set("step", r.Step.String())
+	}
+	return params
+}
+
+// BoardHistory fetches the number of issues per status over time. History
+// calls return ErrDisabled if gvid runs without a data directory.
+func (c *Client) BoardHistory(ctx context.Context, r HistoryRange) (*Series[BoardCounts], error) {
+	var series Series[BoardCounts]
+	if err := c.getJSON(ctx, "/api/v1/history/board", r.values(), &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// AgentHistory fetches agent states over time, limited to one agent
+// address when address is not empty.
+func (c *Client) AgentHistory(ctx context.Context, r HistoryRange, address string) (*Series[[]AgentState], error) {
+	params := r.values()
+	if address != "" {
+		params.Set("address", address)
+	}
+	var series Series[[]AgentState]
+	if err := c.getJSON(ctx, "/api/v1/history/agents", params, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// ConvoyHistory fetches convoy progress over time, limited to one convoy
+// when id is not empty.
+func (c *Client) ConvoyHistory(ctx context.Context, r HistoryRange, id string) (*Series[[]ConvoyState], error) {
+	params := r.values()
+	if id != "" {
+		params.Set("convoy", id)
+	}
+	var series Series[[]ConvoyState]
+	if err := c.getJSON(ctx, "/api/v1/history/convoys", params, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Transitions fetches the recorded issue status changes, limited to one
+// issue when issueID is not empty. Step is ignored.
+func (c *Client) Transitions(ctx context.Context, r HistoryRange, issueID string) ([]IssueTransition, error) {
+	params := r.values()
+	params.Del("step")
+	if issueID != "" {
+		params.Set("issue", issueID)
+	}
+	var list struct {
+		Transitions []IssueTransition `json:"transitions"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/history/transitions", params, &list); err != nil {
+		return nil, err
+	}
+	return list.Transitions, nil
+}
+
+// Snapshot reconstructs the board, agents, convoys and issue statuses as
+// recorded at the given instant, or now if at is zero.
+func (c *Client) Snapshot(ctx context.Context, at time.Time) (*TownSnapshot, error) {
+	params := url.Values{}
+	setTime(params, "at", at)
+	var snap TownSnapshot
+	if err := c.getJSON(ctx, "/api/v1/history/snapshot", params, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
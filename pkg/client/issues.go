@@ -0,0 +1,302 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueQuery narrows the issues of a listing, board, graph or export.
+// Zero fields are not sent.
+type IssueQuery struct {
+	// View applies a saved view; the other fields refine it.
+	View string
+	// Q is a query such as "status:blocked priority:high".
+	Q        string
+	Status   string
+	Parent   string
+	Search   string
+	Priority []Priority
+	Type     []string
+	// UnmetCriteria keeps only closed issues with unchecked acceptance
+	// criteria.
+	UnmetCriteria bool
+	// Sort orders issues by a field, prefixed with "-" for descending
+	// order, such as "-updated".
+	Sort    string
+	GroupBy GroupBy // boards only
+}
+
+func (q IssueQuery) values() url.Values {
+	params := url.Values{}
+	for name, v := range map[string]string{
+		"view": q.View, "q": q.Q, "status": q.Status, "parent": q.Parent,
+		"search": q.Search, "sort": q.Sort, "group_by": string(q.GroupBy),
+	} {
+		if v != "" {
+			params.Set(name, v)
+		}
+	}
+	if len(q.Priority) > 0 {
+		priorities := make([]string, len(q.Priority))
+		for i, p := range q.Priority {
+			priorities[i] = string(p)
+		}
+		params.Set("priority", strings.Join(priorities, ","))
+	}
+	if len(q.Type) > 0 {
+		params.Set("type", strings.Join(q.Type, ","))
+	}
+	if q.UnmetCriteria {
+		params.Set("unmet_criteria", "true")
+	}
+	return params
+}
+
+// setTime sets a time parameter unless t is zero.
+func setTime(params url.Values, name string, t time.Time) {
+	if !t.IsZero() {
+		params.Set(name, t.Format(time.RFC3339))
+	}
+}
+
+// Health reports whether gvid can read beads. A daemon without bd or an
+// initialized beads database answers with Status "error" and the reason in
+// Error; that is not returned as an error.
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	var health Health
+	err := c.getJSON(ctx, "/api/v1/health", nil, &health)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && json.Unmarshal(apiErr.body, &health) == nil && health.Status != "" {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+// ListOptions pages through an issue listing.
+type ListOptions struct {
+	Limit  int
+	Offset int
+	// RenderHTML adds each description rendered as sanitized HTML.
+	RenderHTML bool
+}
+
+// Issues lists the issues matching q.
+func (c *Client) Issues(ctx context.Context, q IssueQuery, opts ListOptions) (*IssueList, error) {
+	params := q.values()
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		params.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if opts.RenderHTML {
+		params.Set("render", "html")
+	}
+	var list IssueList
+	if err := c.getJSON(ctx, "/api/v1/issues", params, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Issue fetches a single issue by ID.
+func (c *Client) Issue(ctx context.Context, id string) (*Issue, error) {
+	var issue Issue
+	if err := c.getJSON(ctx, "/api/v1/issues/"+url.PathEscape(id), nil, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// UpdateIssue applies a partial update to an issue and returns the result.
+func (c *Client) UpdateIssue(ctx context.Context, id string, update IssueUpdate) (*Issue, error) {
+	var issue Issue
+	if err := c.sendJSON(ctx, http.MethodPatch, "/api/v1/issues/"+url.PathEscape(id), nil, update, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// AddDependency records that issue id depends on dep.DependsOn, with
+// dep.Type defaulting to blocks, and returns the updated issue.
+func (c *Client) AddDependency(ctx context.Context, id string, dep Dependency) (*Issue, error) {
+	var issue Issue
+	if err := c.sendJSON(ctx, http.MethodPost, "/api/v1/issues/"+url.PathEscape(id)+"/dependencies", nil, dep, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Timeline fetches an issue's status history.
+func (c *Client) Timeline(ctx context.Context, id string) (*Timeline, error) {
+	var timeline Timeline
+	if err := c.getJSON(ctx, "/api/v1/issues/"+url.PathEscape(id)+"/timeline", nil, &timeline); err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
+
+// Tree fetches an issue with its descendants and their rolled-up progress.
+func (c *Client) Tree(ctx context.Context, id string) (*IssueTree, error) {
+	var tree IssueTree
+	if err := c.getJSON(ctx, "/api/v1/issues/"+url.PathEscape(id)+"/tree", nil, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+// Epics fetches the epics with their progress trees.
+func (c *Client) Epics(ctx context.Context) ([]IssueTree, error) {
+	var list struct {
+		Epics []IssueTree `json:"epics"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/epics", nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Epics, nil
+}
+
+// Board fetches the kanban board of the issues matching q.
+func (c *Client) Board(ctx context.Context, q IssueQuery) (*Board, error) {
+	var board Board
+	if err := c.getJSON(ctx, "/api/v1/board", q.values(), &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// Graph fetches the dependency graph between the issues matching q.
+func (c *Client) Graph(ctx context.Context, q IssueQuery) (*Graph, error) {
+	var graph Graph
+	if err := c.getJSON(ctx, "/api/v1/graph", q.values(), &graph); err != nil {
+		return nil, err
+	}
+	return &graph, nil
+}
+
+// GraphFormat is a text format of the dependency graph.
+type GraphFormat string
+
+const (
+	GraphDOT     GraphFormat = "dot"
+	GraphMermaid GraphFormat = "mermaid"
+)
+
+// GraphText fetches the dependency graph as Graphviz DOT or a Mermaid
+// flowchart.
+func (c *Client) GraphText(ctx context.Context, format GraphFormat, q IssueQuery) (string, error) {
+	params := q.values()
+	params.Set("format", string(format))
+	data, err := c.getBytes(ctx, "/api/v1/graph", params)
+	return string(data), err
+}
+
+// ExportFormat is the format of an issue export.
+type ExportFormat string
+
+const (
+	ExportCSV      ExportFormat = "csv"
+	ExportJSONL    ExportFormat = "jsonl"
+	ExportMarkdown ExportFormat = "markdown"
+)
+
+// ExportOptions selects the format and window of an export. The window
+// bounds the markdown report's closed issues; zero times use gvid's
+// defaults.
+type ExportOptions struct {
+	Format ExportFormat
+	Since  time.Time
+	Until  time.Time
+}
+
+// Export exports the issues matching q.
+func (c *Client) Export(ctx context.Context, q IssueQuery, opts ExportOptions) ([]byte, error) {
+	params := q.values()
+	if opts.Format != "" {
+		params.Set("format", string(opts.Format))
+	}
+	setTime(params, "since", opts.Since)
+	setTime(params, "until", opts.Until)
+	return c.getBytes(ctx, "/api/v1/export", params)
+}
+
+// FlowOptions selects the issues and window of a flow report. Zero fields
+// use gvid's defaults.
+type FlowOptions struct {
+	Since    time.Time
+	Until    time.Time
+	Priority []Priority
+	Type     []string
+	// Aging is how long an issue may stay in progress before it is
+	// reported as aging.
+	Aging time.Duration
+}
+
+// FlowMetrics fetches throughput, work in progress, cycle and lead times
+// and aging issues.
+func (c *Client) FlowMetrics(ctx context.Context, opts FlowOptions) (*FlowMetrics, error) {
+	params := IssueQuery{Priority: opts.Priority, Type: opts.Type}.values()
+	setTime(params, "since", opts.Since)
+	setTime(params, "until", opts.Until)
+	if opts.Aging > 0 {
+		params.Set("aging", opts.Aging.String())
+	}
+	var flow FlowMetrics
+	if err := c.getJSON(ctx, "/api/v1/metrics/flow", params, &flow); err != nil {
+		return nil, err
+	}
+	return &flow, nil
+}
+
+// Views fetches the saved views. It returns ErrDisabled if gvid runs
+// without a data directory.
+func (c *Client) Views(ctx context.Context) ([]SavedView, error) {
+	var list struct {
+		Views []SavedView `json:"views"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/views", nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Views, nil
+}
+
+// View fetches a saved view by name.
+func (c *Client) View(ctx context.Context, name string) (*SavedView, error) {
+	var view SavedView
+	if err := c.getJSON(ctx, "/api/v1/views/"+url.PathEscape(name), nil, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// CreateView saves a new view. It returns ErrConflict if the name is taken.
+func (c *Client) CreateView(ctx context.Context, view SavedView) (*SavedView, error) {
+	var saved SavedView
+	if err := c.sendJSON(ctx, http.MethodPost, "/api/v1/views", nil, view, &saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// PutView creates or replaces the view with the given name.
+func (c *Client) PutView(ctx context.Context, name string, view SavedView) (*SavedView, error) {
+	var saved SavedView
+	if err := c.sendJSON(ctx, http.MethodPut, "/api/v1/views/"+url.PathEscape(name), nil, view, &saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// DeleteView deletes a saved view.
+func (c *Client) DeleteView(ctx context.Context, name string) error {
+	return c.sendJSON(ctx, http.MethodDelete, "/api/v1/views/"+url.PathEscape(name), nil, nil, nil)
+}
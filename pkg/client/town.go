@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// TownStatus fetches the town's health and agent counts.
+func (c *Client) TownStatus(ctx context.Context) (*TownStatus, error) {
+	var status TownStatus
+	if err := c.getJSON(ctx, "/api/v1/town/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Town fetches the town structure: rigs and their agents.
+func (c *Client) Town(ctx context.Context) (*Town, error) {
+	var town Town
+	if err := c.getJSON(ctx, "/api/v1/town", nil, &town); err != nil {
+		return nil, err
+	}
+	return &town, nil
+}
+
+// Rigs fetches the town's rigs.
+func (c *Client) Rigs(ctx context.Context) ([]Rig, error) {
+	var list struct {
+		Rigs []Rig `json:"rigs"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/rigs", nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Rigs, nil
+}
+
+// Rig fetches a rig by name.
+func (c *Client) Rig(ctx context.Context, name string) (*Rig, error) {
+	var rig Rig
+	if err := c.getJSON(ctx, "/api/v1/town/rigs/"+url.PathEscape(name), nil, &rig); err != nil {
+		return nil, err
+	}
+	return &rig, nil
+}
+
+// Agents fetches every agent in the town.
+func (c *Client) Agents(ctx context.Context) ([]Agent, error) {
+	var list struct {
+		Agents []Agent `json:"agents"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/agents", nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Agents, nil
+}
+
+// Convoys fetches the active convoys.
+func (c *Client) Convoys(ctx context.Context) ([]Convoy, error) {
+	var list struct {
+		Convoys []Convoy `json:"convoys"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/convoys", nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Convoys, nil
+}
+
+// Convoy fetches a convoy by ID.
+func (c *Client) Convoy(ctx context.Context, id string) (*Convoy, error) {
+	var convoy Convoy
+	if err := c.getJSON(ctx, "/api/v1/town/convoys/"+url.PathEscape(id), nil, &convoy); err != nil {
+		return nil, err
+	}
+	return &convoy, nil
+}
+
+// MoleculeQuery selects molecules. The zero value selects the active ones.
+type MoleculeQuery struct {
+	// Include adds runs from gvid's local archive: "completed" for finished
+	// and failed runs, "all" for every archived run.
+	Include string
+	// Since and Until keep molecules active in that window; zero times
+	// leave that end open.
+	Since, Until time.Time
+}
+
+// Molecules fetches the molecules selected by q with their steps. Archived
+// runs are marked Archived. Including them fails with ErrDisabled when gvid
+// runs without its local archive.
+func (c *Client) Molecules(ctx context.Context, q MoleculeQuery) ([]Molecule, error) {
+	params := url.Values{}
+	if q.Include != "" {
+		params.Set("include", q.Include)
+	}
+	setTime(params, "since", q.Since)
+	setTime(params, "until", q.Until)
+	var list struct {
+		Molecules []Molecule `json:"molecules"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/molecules", params, &list); err != nil {
+		return nil, err
+	}
+	return list.Molecules, nil
+}
+
+// Molecule fetches a molecule by ID, including archived molecules whose
+// agent has been recycled.
+func (c *Client) Molecule(ctx context.Context, id string) (*Molecule, error) {
+	var molecule Molecule
+	if err := c.getJSON(ctx, "/api/v1/town/molecules/"+url.PathEscape(id), nil, &molecule); err != nil {
+		return nil, err
+	}
+	return &molecule, nil
+}
+
+// MoleculeGraph fetches a molecule's step DAG with step timings and its
+// critical path.
+func (c *Client) MoleculeGraph(ctx context.Context, id string) (*MoleculeGraph, error) {
+	var graph MoleculeGraph
+	if err := c.getJSON(ctx, "/api/v1/town/molecules/"+url.PathEscape(id)+"/graph", nil, &graph); err != nil {
+		return nil, err
+	}
+	return &graph, nil
+}
+
+// MoleculeGraphDOT fetches a molecule's step DAG as Graphviz DOT.
+func (c *Client) MoleculeGraphDOT(ctx context.Context, id string) (string, error) {
+	data, err := c.getBytes(ctx, "/api/v1/town/molecules/"+url.PathEscape(id)+"/graph", url.Values{"format": {"dot"}})
+	return string(data), err
+}
+
+// FormulaStats fetches run statistics per formula for molecules active
+// between since and until; zero times leave that end open.
+func (c *Client) FormulaStats(ctx context.Context, since, until time.Time) ([]FormulaStats, error) {
+	params := url.Values{}
+	setTime(params, "since", since)
+	setTime(params, "until", until)
+	var list struct {
+		Formulas []FormulaStats `json:"formulas"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/formulas/stats", params, &list); err != nil {
+		return nil, err
+	}
+	return list.Formulas, nil
+}
+
+// Mail fetches the inbox of an agent address such as "gastown/nux".
+func (c *Client) Mail(ctx context.Context, address string) ([]Message, error) {
+	var list struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/town/mail/"+url.PathEscape(address), nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Messages, nil
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"time"
+
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/beads"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/gastown"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/metrics"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/model"
+	"github.com/intent-solutions-io/gastown-viewer-intent/internal/store"
+)
+
+// The API's types are defined in gvid's internal packages; these aliases
+// make them usable outside the module.
+
+// Issues and boards
+type (
+	Issue            = model.Issue
+	IssueSummary     = model.IssueSummary
+	IssueList        = model.IssueListResponse
+	IssueUpdate      = model.IssueUpdate
+	IssueTree        = model.IssueTree
+	Status           = model.Status
+	Priority         = model.Priority
+	Dependency       = model.Dependency
+	EdgeType         = model.EdgeType
+	Board            = model.Board
+	Column           = model.Column
+	GroupBy          = model.GroupBy
+	Graph            = model.Graph
+	Timeline         = model.Timeline
+	SavedView        = model.SavedView
+	FlowMetrics      = metrics.FlowMetrics
+	UnmappedStatus   = beads.UnmappedStatus
+	AgentRef         = model.AgentRef
+	ConvoyRef        = model.ConvoyRef
+	CriteriaProgress = model.CriteriaProgress
+)
+
+// Gas Town
+type (
+	TownStatus     = gastown.TownStatus
+	Town           = gastown.Town
+	Rig            = gastown.Rig
+	Agent          = gastown.Agent
+	Convoy         = gastown.Convoy
+	Molecule       = gastown.Molecule
+	MoleculeStep   = gastown.MoleculeStep
+	MoleculeTiming = gastown.MoleculeTiming
+	StepTiming     = gastown.StepTiming
+	FormulaStats   = gastown.FormulaStats
+	Message        = gastown.Message
+	Role           = gastown.Role
+	AgentStatus    = gastown.AgentStatus
+	ConvoyStatus   = gastown.ConvoyStatus
+	MoleculeStatus = gastown.MoleculeStatus
+)
+
+// History
+type (
+	Sample[T any]   = store.Sample[T]
+	BoardCounts     = store.BoardCounts
+	AgentState      = store.AgentState
+	ConvoyState     = store.ConvoyState
+	IssueTransition = store.IssueTransition
+	TownSnapshot    = store.TownSnapshot
+)
+
+// The enumerations' values are re-exported too, so that callers can
+// compare and filter without importing gvid's internal packages.
+
+// Issue statuses, normalized from bd's raw statuses.
+const (
+	StatusPending    = model.StatusPending
+	StatusInProgress = model.StatusInProgress
+	StatusDone       = model.StatusDone
+	StatusBlocked    = model.StatusBlocked
+	StatusUnknown    = model.StatusUnknown
+)
+
+// Issue priorities, from most to least urgent.
+const (
+	PriorityCritical = model.PriorityCritical
+	PriorityHigh     = model.PriorityHigh
+	PriorityMedium   = model.PriorityMedium
+	PriorityLow      = model.PriorityLow
+	PriorityBacklog  = model.PriorityBacklog
+)
+
+// Dependency edge types.
+const (
+	EdgeTypeBlocks      = model.EdgeTypeBlocks
+	EdgeTypeBlockedBy   = model.EdgeTypeBlockedBy
+	EdgeTypeParent      = model.EdgeTypeParent
+	EdgeTypeChild       = model.EdgeTypeChild
+	EdgeTypeWaitsFor    = model.EdgeTypeWaitsFor
+	EdgeTypeWaitedBy    = model.EdgeTypeWaitedBy
+	EdgeTypeConditional = model.EdgeTypeConditional
+	EdgeTypeRelates     = model.EdgeTypeRelates
+	EdgeTypeDuplicates  = model.EdgeTypeDuplicates
+	EdgeTypeMentions    = model.EdgeTypeMentions
+	EdgeTypeDerivedFrom = model.EdgeTypeDerivedFrom
+	EdgeTypeSupersedes  = model.EdgeTypeSupersedes
+	EdgeTypeImplements  = model.EdgeTypeImplements
+	EdgeTypeUnknown     = model.EdgeTypeUnknown
+)
+
+// Board swimlane groupings.
+const (
+	GroupByNone     = model.GroupByNone
+	GroupByPriority = model.GroupByPriority
+	GroupByType     = model.GroupByType
+	GroupByAssignee = model.GroupByAssignee
+	GroupByRig      = model.GroupByRig
+	GroupByParent   = model.GroupByParent
+)
+
+// Gas Town agent roles.
+const (
+	RoleMayor    = gastown.RoleMayor
+	RoleDeacon   = gastown.RoleDeacon
+	RoleWitness  = gastown.RoleWitness
+	RoleRefinery = gastown.RoleRefinery
+	RoleCrew     = gastown.RoleCrew
+	RolePolecat  = gastown.RolePolecat
+)
+
+// Agent statuses.
+const (
+	AgentActive  = gastown.StatusActive
+	AgentIdle    = gastown.StatusIdle
+	AgentStuck   = gastown.StatusStuck
+	AgentOffline = gastown.StatusOffline
+	AgentUnknown = gastown.StatusUnknown
+)
+
+// Convoy statuses.
+const (
+	ConvoyPending    = gastown.ConvoyStatusPending
+	ConvoyInProgress = gastown.ConvoyStatusInProgress
+	ConvoyComplete   = gastown.ConvoyStatusComplete
+	ConvoyBlocked    = gastown.ConvoyStatusBlocked
+	ConvoyFailed     = gastown.ConvoyStatusFailed
+)
+
+// Molecule statuses.
+const (
+	MoleculePending    = gastown.MolStatusPending
+	MoleculeInProgress = gastown.MolStatusInProgress
+	MoleculeComplete   = gastown.MolStatusComplete
+	MoleculeBlocked    = gastown.MolStatusBlocked
+	MoleculeFailed     = gastown.MolStatusFailed
+)
+
+// Health is the response of GET /api/v1/health.
+type Health struct {
+	Status           string           `json:"status"` // "ok" or "error"
+	BeadsInitialized bool             `json:"beads_initialized"`
+	Version          string           `json:"version"`
+	BDVersion        string           `json:"bd_version,omitempty"`
+	Error            string           `json:"error,omitempty"`
+	UnmappedStatuses []UnmappedStatus `json:"unmapped_statuses,omitempty"`
+}
+
+// MoleculeGraph is a molecule's step DAG with its timing analysis.
+type MoleculeGraph struct {
+	Graph
+	MoleculeTiming
+}
+
+// Series is a history time series.
+type Series[T any] struct {
+	Samples []Sample[T] `json:"samples"`
+	Since   time.Time   `json:"since"`
+	Until   time.Time   `json:"until"`
+	Step    string      `json:"step"`
+}
@@ -1,6 +1,6 @@
 import { useEffect, useState } from 'react';
-import type { BoardResponse, Issue, Column, IssueSummary, Town, TownStatus, Agent, Rig, Molecule, Convoy } from './api';
-import { fetchBoard, fetchIssue, fetchTown, fetchTownStatus, fetchMolecules, fetchConvoys } from './api';
+import type { BoardResponse, Issue, Column, IssueSummary, Town, TownStatus, Agent, Rig, Molecule, Convoy, SavedView } from './api';
+import { fetchBoard, fetchIssue, fetchTown, fetchTownStatus, fetchMolecules, fetchConvoys, fetchViews, exportURL } from './api';
 import DependencyGraph from './components/DependencyGraph';
 import './App.css';
 
@@ -40,6 +40,11 @@ function IssueCard({
       <div className="issue-meta">
         <StatusBadge status={issue.status} />
         <span className="issue-priority">{issue.priority}</span>
+        {issue.agent && (
+          <span className={`agent-badge agent-${issue.agent.status}`} title={issue.agent.address}>
+            @{issue.agent.name}
+          </span>
+        )}
       </div>
     </div>
   );
@@ -94,16 +99,56 @@ function IssueDetail({
         {issue.description && (
           <div className="issue-section">
             <h3>Description</h3>
-            <p className="issue-description">{issue.description}</p>
+            {issue.description_html ? (
+              // Rendered and sanitized by gvid
+              <div className="issue-description" dangerouslySetInnerHTML={{ __html: issue.description_html }} />
+            ) : (
+              <p className="issue-description">{issue.description}</p>
+            )}
+          </div>
+        )}
+
+        {issue.criteria && issue.criteria.length > 0 && (
+          <div className="issue-section">
+            <h3>
+              Done When
+              {issue.criteria_progress &&
+                ` (${issue.criteria_progress.checked}/${issue.criteria_progress.total})`}
+            </h3>
+            <ul>
+              {issue.criteria.map((item, i) => (
+                <li key={i}>
+                  {item.checkbox && <input type="checkbox" checked={item.checked} readOnly />} {item.text}
+                </li>
+              ))}
+            </ul>
+          </div>
+        )}
+
+        {issue.agents && issue.agents.length > 0 && (
+          <div className="issue-section">
+            <h3>Worked On By</h3>
+            <ul>
+              {issue.agents.map((agent) => (
+                <li key={agent.address}>
+                  <span className={`agent-badge agent-${agent.status}`}>@{agent.name}</span> {agent.status}{' '}
+                  <span className="dep-id">
+                    ({agent.address} via {agent.via})
+                  </span>
+                </li>
+              ))}
+            </ul>
           </div>
         )}
 
-        {issue.done_when && issue.done_when.length > 0 && (
+        {issue.convoys && issue.convoys.length > 0 && (
           <div className="issue-section">
-            <h3>Done When</h3>
+            <h3>Convoys</h3>
             <ul>
-              {issue.done_when.map((item, i) => (
-                <li key={i}>{item}</li>
+              {issue.convoys.map((convoy) => (
+                <li key={convoy.id}>
+                  {convoy.title} <span className="dep-id">({convoy.id})</span> {convoy.progress}/{convoy.total}
+                </li>
               ))}
             </ul>
           </div>
@@ -492,6 +537,8 @@ function TownView({ town, status, molecules, convoys }: { town: Town | null; sta
 function App() {
   const [viewMode, setViewMode] = useState<ViewMode>('beads');
   const [board, setBoard] = useState<BoardResponse | null>(null);
+  const [views, setViews] = useState<SavedView[]>([]);
+  const [activeView, setActiveView] = useState('');
   const [selectedIssue, setSelectedIssue] = useState<Issue | null>(null);
   const [town, setTown] = useState<Town | null>(null);
   const [townStatus, setTownStatus] = useState<TownStatus | null>(null);
@@ -500,16 +547,21 @@ function App() {
   const [error, setError] = useState<string | null>(null);
   const [loading, setLoading] = useState(true);
 
+  useEffect(() => {
+    // Saved views are unavailable when gvid runs without a data directory
+    fetchViews().then(setViews).catch(() => setViews([]));
+  }, []);
+
   useEffect(() => {
     loadData();
     const interval = setInterval(loadData, 5000);
     return () => clearInterval(interval);
-  }, []);
+  }, [activeView]);
 
   async function loadData() {
     try {
       const [boardData, townData, statusData, moleculesData, convoysData] = await Promise.all([
-        fetchBoard().catch(() => null),
+        fetchBoard(activeView || undefined).catch(() => null),
         fetchTown().catch(() => null),
         fetchTownStatus().catch(() => null),
         fetchMolecules().catch(() => null),
@@ -583,11 +635,35 @@ function App() {
         </div>
       </header>
 
+      {viewMode === 'beads' && (
+        <div className="board-toolbar">
+          <select
+            className="view-picker"
+            aria-label="Saved view"
+            value={activeView}
+            onChange={(e) => setActiveView(e.target.value)}
+          >
+            <option value="">All issues</option>
+            {views.map((view) => (
+              <option key={view.name} value={view.name} title={view.description}>
+                {view.name}
+              </option>
+            ))}
+          </select>
+          <a className="tab" href={exportURL('csv', activeView || undefined)} download>
+            Export CSV
+          </a>
+          <a className="tab" href={exportURL('markdown', activeView || undefined)} download>
+            Report
+          </a>
+        </div>
+      )}
+
       {viewMode === 'beads' && (
         <div className="board">
           {board?.columns.map((column) => (
             <BoardColumn
-              key={column.status}
+              key={column.id}
               column={column}
               onIssueClick={handleIssueClick}
             />
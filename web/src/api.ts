@@ -2,14 +2,54 @@
 
 const API_BASE = '/api/v1';
 
-export type Status = 'pending' | 'in_progress' | 'done' | 'blocked';
-export type Priority = 'critical' | 'high' | 'medium' | 'low';
+export type Status = 'pending' | 'in_progress' | 'done' | 'blocked' | 'unknown';
+export type Priority = 'critical' | 'high' | 'medium' | 'low' | 'backlog';
+
+export interface Criterion {
+  text: string;
+  section?: string;
+  checkbox: boolean;
+  checked: boolean;
+}
+
+export interface CriteriaProgress {
+  total: number;
+  checked: number;
+  percent: number;
+}
 
 export interface IssueSummary {
   id: string;
   title: string;
   status: Status;
+  raw_status?: string;
   priority: Priority;
+  issue_type?: string;
+  assignee?: string;
+  labels?: string[];
+  updated_at?: string;
+  closed_at?: string;
+  criteria?: CriteriaProgress;
+  agent?: AgentRef;
+}
+
+export interface AgentRef {
+  address: string;
+  name: string;
+  role: string;
+  rig?: string;
+  status: string;
+  via: 'hook' | 'molecule';
+  molecule?: string;
+  last_active?: string;
+}
+
+export interface ConvoyRef {
+  id: string;
+  title: string;
+  status: string;
+  progress: number;
+  total: number;
 }
 
 export interface Issue {
@@ -17,19 +57,34 @@ export interface Issue {
   title: string;
   description: string;
   status: Status;
+  raw_status?: string;
   priority: Priority;
+  issue_type?: string;
+  assignee?: string;
+  labels?: string[];
+  closed_at?: string;
   parent?: IssueSummary;
   children: IssueSummary[];
   blocks: IssueSummary[];
   blocked_by: IssueSummary[];
   done_when: string[];
+  criteria?: Criterion[];
+  criteria_progress?: CriteriaProgress;
+  description_html?: string;
+  agents?: AgentRef[];
+  convoys?: ConvoyRef[];
   created_at: string;
   updated_at: string;
 }
 
 export interface Column {
-  status: Status;
+  id: string;
+  // status is set when the column holds one normalized status.
+  status?: Status;
   label: string;
+  statuses?: string[];
+  wip_limit?: number;
+  over_limit?: boolean;
   count: number;
   issues: IssueSummary[];
 }
@@ -45,6 +100,7 @@ export interface HealthResponse {
   version: string;
   bd_version?: string;
   error?: string;
+  unmapped_statuses?: { status: string; example: string; first_seen: string; last_seen: string }[];
 }
 
 // Graph Types
@@ -68,7 +124,12 @@ export interface GraphNode {
   id: string;
   title: string;
   status: Status;
+  raw_status?: string;
   priority: Priority;
+  issue_type?: string;
+  assignee?: string;
+  labels?: string[];
+  closed_at?: string;
 }
 
 export interface GraphEdge {
@@ -95,14 +156,46 @@ export async function fetchHealth(): Promise<HealthResponse> {
   return res.json();
 }
 
-export async function fetchBoard(): Promise<BoardResponse> {
-  const res = await fetch(`${API_BASE}/board`);
+export async function fetchBoard(view?: string): Promise<BoardResponse> {
+  const query = view ? `?view=${encodeURIComponent(view)}` : '';
+  const res = await fetch(`${API_BASE}/board${query}`);
   if (!res.ok) throw new Error(`HTTP ${res.status}`);
   return res.json();
 }
 
+// exportURL returns a download link for the issues in a saved view, or all
+// issues without one.
+export function exportURL(format: 'csv' | 'jsonl' | 'markdown', view?: string): string {
+  const params = new URLSearchParams({ format });
+  if (view) params.set('view', view);
+  return `${API_BASE}/export?${params}`;
+}
+
+export interface SavedView {
+  name: string;
+  description?: string;
+  status?: string;
+  parent?: string;
+  search?: string;
+  priority?: Priority[];
+  type?: string[];
+  unmet_criteria?: boolean;
+  query?: string;
+  sort?: string;
+  group_by?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export async function fetchViews(): Promise<SavedView[]> {
+  const res = await fetch(`${API_BASE}/views`);
+  if (!res.ok) throw new Error(`HTTP ${res.status}`);
+  const data: { views: SavedView[] } = await res.json();
+  return data.views;
+}
+
 export async function fetchIssue(id: string): Promise<Issue> {
-  const res = await fetch(`${API_BASE}/issues/${encodeURIComponent(id)}`);
+  const res = await fetch(`${API_BASE}/issues/${encodeURIComponent(id)}?render=html`);
   if (!res.ok) throw new Error(`HTTP ${res.status}`);
   return res.json();
 }